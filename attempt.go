@@ -0,0 +1,61 @@
+package recovererr
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Attempt describes a call of the function by the retry mechanism.
+type Attempt struct {
+	// Number is the attempt number, starting from 1.
+	Number int
+	// Start is the time the attempt started.
+	Start time.Time
+	// Elapsed is the time elapsed since the first attempt started.
+	Elapsed time.Duration
+	// PreviousError is the error returned by the previous attempt.
+	PreviousError error
+	// Waited is the delay waited before the attempt, zero for the first attempt.
+	// The delay before the next attempt depends on the error of the attempt,
+	// and is reported by WithOnRetry.
+	Waited time.Duration
+}
+
+// ErrAttemptTimeout is wrapped by the error of an attempt exceeding
+// the timeout configured by WithAttemptTimeout.
+var ErrAttemptTimeout = errors.New("attempt timeout")
+
+type attemptTimeoutError struct {
+	err error
+}
+
+// Error returns the error in string format.
+func (ate *attemptTimeoutError) Error() string {
+	return ErrAttemptTimeout.Error() + ": " + ate.err.Error()
+}
+
+// Is reports the error as ErrAttemptTimeout.
+func (ate *attemptTimeoutError) Is(target error) bool {
+	return target == ErrAttemptTimeout
+}
+
+// Unwrap provides the error of the attempt.
+func (ate *attemptTimeoutError) Unwrap() error {
+	return ate.err
+}
+
+// isAttemptTimeout reports if the error of an attempt that exceeded its timeout
+// is caused by the timeout, unless the error carries its own recovery context.
+func isAttemptTimeout(err error) bool {
+	if found, _ := doRecover(err, ResolveAnyUnrecoverable, unclassified); found {
+		return false
+	}
+	found, _ := DoRecover(err)
+	return !found || errors.Is(err, context.DeadlineExceeded)
+}
+
+// unclassified classifies no error, to find the recovery context carried by the error chain.
+func unclassified(error) (bool, bool) {
+	return false, false
+}
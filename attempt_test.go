@@ -0,0 +1,171 @@
+package recovererr
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryAttempt(t *testing.T) {
+	t.Parallel()
+
+	t.Run("attempts of retry", func(t *testing.T) {
+		var (
+			attempts []Attempt
+			failure  = Recoverable(errors.New("failure"))
+		)
+		f := func(ctx context.Context, a Attempt) error {
+			attempts = append(attempts, a)
+			if a.Number < 3 {
+				return failure
+			}
+			return nil
+		}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		err := retryAttempt(context.Background(), f, &mockClock, NewConstantBackoff(WithInterval(time.Millisecond)), RetryRecoverablePolicy)
+
+		assert.Nil(t, err)
+		assert.Len(t, attempts, 3)
+		for i, a := range attempts {
+			assert.Equal(t, i+1, a.Number)
+			assert.False(t, a.Start.IsZero())
+			assert.True(t, a.Elapsed >= 0)
+		}
+		assert.Nil(t, attempts[0].PreviousError)
+		assert.Equal(t, time.Duration(0), attempts[0].Waited)
+		assert.Equal(t, failure, attempts[1].PreviousError)
+		assert.Equal(t, time.Millisecond, attempts[1].Waited)
+		assert.True(t, attempts[2].Elapsed >= attempts[1].Elapsed)
+	})
+
+	t.Run("attempts of do", func(t *testing.T) {
+		var numbers []int
+		f := func(ctx context.Context, a Attempt) error {
+			numbers = append(numbers, a.Number)
+			return errors.New("failure")
+		}
+		newBackoff := func() BackoffStrategy {
+			return NewConstantBackoff(WithInterval(time.Millisecond), WithMaxAttempts(2))
+		}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		err := doAttempt(context.Background(), f, &mockClock, newBackoff, RetryNonUnrecoverablePolicy)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, []int{1, 2, 3}, numbers)
+	})
+
+	t.Run("context passed to function", func(t *testing.T) {
+		ctx, cancelFunc := context.WithCancel(context.Background())
+		defer cancelFunc()
+		f := func(fctx context.Context, a Attempt) error {
+			assert.Equal(t, ctx, fctx)
+			return nil
+		}
+
+		assert.Nil(t, RetryAttempt(ctx, f, NewConstantBackoff(), RetryRecoverablePolicy))
+		assert.Nil(t, DoAttempt(ctx, f, func() BackoffStrategy { return NewConstantBackoff() }, RetryRecoverablePolicy))
+	})
+}
+
+func TestRetryAttempt_attemptTimeout(t *testing.T) {
+	t.Parallel()
+
+	blockUntilDone := func(ctx context.Context, a Attempt) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	t.Run("retry attempts exceeding timeout", func(t *testing.T) {
+		var numbers []int
+		f := func(ctx context.Context, a Attempt) error {
+			numbers = append(numbers, a.Number)
+			if a.Number < 3 {
+				return blockUntilDone(ctx, a)
+			}
+			return nil
+		}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		err := retryAttempt(context.Background(), f, &mockClock, NewConstantBackoff(WithInterval(time.Millisecond)), RetryRecoverablePolicy, WithAttemptTimeout(time.Millisecond))
+
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1, 2, 3}, numbers)
+	})
+
+	t.Run("attempt timeout ends retries", func(t *testing.T) {
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		err := retryAttempt(context.Background(), blockUntilDone, &mockClock, NewConstantBackoff(WithInterval(time.Millisecond), WithMaxAttempts(2)), RetryRecoverablePolicy, WithAttemptTimeout(time.Millisecond))
+
+		assert.True(t, errors.Is(err, ErrAttemptTimeout), err)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded), err)
+		found, recover := DoRecover(err)
+		assert.True(t, found)
+		assert.True(t, recover)
+		assert.Len(t, mockClock.delays, 2)
+	})
+
+	t.Run("recovery context of slow attempt is kept", func(t *testing.T) {
+		attempts := 0
+		f := func(ctx context.Context, a Attempt) error {
+			attempts++
+			<-ctx.Done()
+			return Unrecoverable(errors.New("invalid request"))
+		}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		err := retryAttempt(context.Background(), f, &mockClock, NewConstantBackoff(WithInterval(time.Millisecond)), RetryRecoverablePolicy, WithAttemptTimeout(time.Millisecond))
+
+		assert.False(t, errors.Is(err, ErrAttemptTimeout), err)
+		assert.Equal(t, "unrecover: invalid request", err.Error())
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("unclassified error of slow attempt times out", func(t *testing.T) {
+		f := func(ctx context.Context, a Attempt) error {
+			<-ctx.Done()
+			return errors.New("connection closed")
+		}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		err := retryAttempt(context.Background(), f, &mockClock, NewConstantBackoff(WithInterval(time.Millisecond), WithMaxAttempts(1)), RetryRecoverablePolicy, WithAttemptTimeout(time.Millisecond))
+
+		assert.True(t, errors.Is(err, ErrAttemptTimeout), err)
+		assert.Len(t, mockClock.delays, 1)
+	})
+
+	t.Run("parent deadline is terminal", func(t *testing.T) {
+		ctx, cancelFunc := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancelFunc()
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		attempts := 0
+		f := func(ctx context.Context, a Attempt) error {
+			attempts++
+			return blockUntilDone(ctx, a)
+		}
+
+		err := retryAttempt(ctx, f, &mockClock, NewConstantBackoff(WithInterval(time.Millisecond)), RetryNonUnrecoverablePolicy, WithAttemptTimeout(time.Hour))
+
+		assert.False(t, errors.Is(err, ErrAttemptTimeout), err)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("attempt timeout of value function", func(t *testing.T) {
+		f := func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 1, ctx.Err()
+		}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		v, err := retryValue(context.Background(), f, &mockClock, NewConstantBackoff(WithInterval(time.Millisecond), WithMaxAttempts(1)), RetryRecoverablePolicy, WithAttemptTimeout(time.Millisecond))
+
+		assert.True(t, errors.Is(err, ErrAttemptTimeout), err)
+		assert.Equal(t, 0, v)
+	})
+}
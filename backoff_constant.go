@@ -53,3 +53,15 @@ func (cb *ConstantBackoff) Next() (time.Duration, bool) {
 	}
 	return cb.interval, true
 }
+
+// Reset implements the ResettableBackoffStrategy.Reset method.
+func (cb *ConstantBackoff) Reset() {
+	cb.attempt = 0
+}
+
+// Clone implements the CloneableBackoffStrategy.Clone method.
+func (cb *ConstantBackoff) Clone() BackoffStrategy {
+	clone := *cb
+	clone.Reset()
+	return &clone
+}
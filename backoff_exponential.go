@@ -88,3 +88,15 @@ func (eb *ExponentialBackoff) Next() (time.Duration, bool) {
 
 	return d, true
 }
+
+// Reset implements the ResettableBackoffStrategy.Reset method.
+func (eb *ExponentialBackoff) Reset() {
+	eb.impl.Reset()
+}
+
+// Clone implements the CloneableBackoffStrategy.Clone method.
+func (eb *ExponentialBackoff) Clone() BackoffStrategy {
+	clone := *eb
+	clone.Reset()
+	return &clone
+}
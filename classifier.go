@@ -0,0 +1,81 @@
+package recovererr
+
+import "sync"
+
+// ClassifierFunc provides the recovery context of errors carrying none,
+// such as errors of third-party packages.
+// It returns false in the first value when the error is not classified.
+type ClassifierFunc func(error) (found, recover bool)
+
+// Classifier is an ordered chain of classifier functions.
+// The first function classifying an error provides its recovery context.
+type Classifier []ClassifierFunc
+
+// Classify provides the recovery context of the given error
+// using the classifier functions in order.
+func (c Classifier) Classify(err error) (bool, bool) {
+	for _, classify := range c {
+		if found, recover := classify(err); found {
+			return true, recover
+		}
+	}
+	return false, false
+}
+
+// DoRecover works like the package DoRecover function, consulting
+// the classifier instead of the registered classifiers.
+func (c Classifier) DoRecover(err error) (bool, bool) {
+	return c.DoRecoverWith(err, ResolveAnyUnrecoverable)
+}
+
+// DoRecoverWith works like the package DoRecoverWith function, consulting
+// the classifier instead of the registered classifiers.
+func (c Classifier) DoRecoverWith(err error, resolution Resolution) (bool, bool) {
+	return doRecover(err, resolution, c.Classify)
+}
+
+// RetryRecoverablePolicy works like the package RetryRecoverablePolicy,
+// consulting the classifier instead of the registered classifiers.
+func (c Classifier) RetryRecoverablePolicy(err error) bool {
+	if err == nil {
+		return false
+	}
+	found, recover := c.DoRecover(err)
+	return found && recover
+}
+
+// RetryNonUnrecoverablePolicy works like the package RetryNonUnrecoverablePolicy,
+// consulting the classifier instead of the registered classifiers.
+func (c Classifier) RetryNonUnrecoverablePolicy(err error) bool {
+	if err == nil {
+		return false
+	}
+	found, recover := c.DoRecover(err)
+	return !found || recover
+}
+
+var (
+	classifiersMu sync.RWMutex
+	classifiers   Classifier
+)
+
+// RegisterClassifier registers a classifier function consulted by DoRecover
+// when no recovery context is found in the error chain.
+// Classifier functions are consulted in registration order.
+func RegisterClassifier(classify ClassifierFunc) {
+	if classify == nil {
+		panic("recoverror: classifier cannot be nil")
+	}
+	classifiersMu.Lock()
+	defer classifiersMu.Unlock()
+
+	classifiers = append(classifiers, classify)
+}
+
+// registeredClassify classifies the given error using the registered classifiers.
+func registeredClassify(err error) (bool, bool) {
+	classifiersMu.RLock()
+	defer classifiersMu.RUnlock()
+
+	return classifiers.Classify(err)
+}
@@ -0,0 +1,136 @@
+package recovererr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errThirdPartyTimeout = errors.New("third-party timeout")
+	errThirdPartyDenied  = errors.New("third-party denied")
+)
+
+func classifyThirdParty(err error) (bool, bool) {
+	switch {
+	case errors.Is(err, errThirdPartyTimeout):
+		return true, true
+	case errors.Is(err, errThirdPartyDenied):
+		return true, false
+	}
+	return false, false
+}
+
+func TestRegisterClassifier(t *testing.T) {
+	classifiersMu.Lock()
+	registered := classifiers
+	classifiersMu.Unlock()
+	defer func() {
+		classifiersMu.Lock()
+		classifiers = registered
+		classifiersMu.Unlock()
+	}()
+
+	RegisterClassifier(classifyThirdParty)
+	RegisterClassifier(func(err error) (bool, bool) {
+		return errors.Is(err, errThirdPartyTimeout), false
+	})
+
+	t.Run("classified error", func(t *testing.T) {
+		found, recover := DoRecover(fmt.Errorf("call failed, %w", errThirdPartyTimeout))
+		assert.True(t, found)
+		assert.True(t, recover, "expected first registered classifier to win")
+	})
+
+	t.Run("recovery context takes precedence", func(t *testing.T) {
+		found, recover := DoRecover(Unrecoverable(errThirdPartyTimeout))
+		assert.True(t, found)
+		assert.False(t, recover)
+	})
+
+	t.Run("classified branches of multi-error", func(t *testing.T) {
+		found, recover := DoRecoverWith(&joinError{[]error{errThirdPartyTimeout, Recoverable(errors.New("connection error"))}}, ResolveAllRecoverable)
+		assert.True(t, found)
+		assert.True(t, recover)
+	})
+
+	t.Run("classified recovery class", func(t *testing.T) {
+		found, class := DoClassify(errThirdPartyDenied)
+		assert.True(t, found)
+		assert.Equal(t, ClassPermanent, class)
+	})
+
+	t.Run("policies", func(t *testing.T) {
+		assert.True(t, RetryRecoverablePolicy(errThirdPartyTimeout))
+		assert.False(t, RetryNonUnrecoverablePolicy(errThirdPartyDenied))
+	})
+
+	t.Run("nil classifier", func(t *testing.T) {
+		defer func() {
+			assert.NotNil(t, recover(), "expected panic")
+		}()
+
+		RegisterClassifier(nil)
+	})
+}
+
+func TestClassifier(t *testing.T) {
+	t.Parallel()
+
+	classifier := Classifier{classifyThirdParty}
+
+	t.Run("classified error", func(t *testing.T) {
+		found, recover := classifier.DoRecover(errThirdPartyTimeout)
+		assert.True(t, found)
+		assert.True(t, recover)
+	})
+
+	t.Run("not classified error", func(t *testing.T) {
+		found, recover := classifier.DoRecover(errors.New("any error"))
+		assert.False(t, found)
+		assert.False(t, recover)
+	})
+
+	t.Run("recovery context takes precedence", func(t *testing.T) {
+		found, recover := classifier.DoRecover(Recoverable(errThirdPartyDenied))
+		assert.True(t, found)
+		assert.True(t, recover)
+	})
+
+	t.Run("resolution of multiple errors", func(t *testing.T) {
+		err := &joinError{[]error{errThirdPartyTimeout, errThirdPartyDenied}}
+		found, recover := classifier.DoRecoverWith(err, ResolveFirstFound)
+		assert.True(t, found)
+		assert.True(t, recover)
+
+		found, recover = classifier.DoRecover(err)
+		assert.True(t, found)
+		assert.False(t, recover)
+	})
+
+	t.Run("recoverable policy", func(t *testing.T) {
+		assert.True(t, classifier.RetryRecoverablePolicy(errThirdPartyTimeout))
+		assert.False(t, classifier.RetryRecoverablePolicy(errThirdPartyDenied))
+		assert.False(t, classifier.RetryRecoverablePolicy(nil))
+	})
+
+	t.Run("non unrecoverable policy", func(t *testing.T) {
+		assert.True(t, classifier.RetryNonUnrecoverablePolicy(errors.New("any error")))
+		assert.False(t, classifier.RetryNonUnrecoverablePolicy(errThirdPartyDenied))
+		assert.False(t, classifier.RetryNonUnrecoverablePolicy(nil))
+	})
+
+	t.Run("per call override", func(t *testing.T) {
+		action := &mockAction{errors: []error{errThirdPartyTimeout, nil}}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		err := retry(context.Background(), action.Call, &mockClock, NewConstantBackoff(WithInterval(time.Millisecond)), classifier.RetryRecoverablePolicy)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, action.callCounter)
+	})
+}
@@ -0,0 +1,10 @@
+//go:build go1.20
+
+package recovererr
+
+import "context"
+
+// contextCause provides the cause of the context cancellation.
+func contextCause(ctx context.Context) error {
+	return context.Cause(ctx)
+}
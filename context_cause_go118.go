@@ -0,0 +1,10 @@
+//go:build !go1.20
+
+package recovererr
+
+import "context"
+
+// contextCause provides no cause, as context.Cause requires Go 1.20.
+func contextCause(context.Context) error {
+	return nil
+}
@@ -0,0 +1,31 @@
+//go:build go1.20
+
+package recovererr
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextError_cause(t *testing.T) {
+	t.Parallel()
+
+	var (
+		shutdown = errors.New("shutting down")
+		failure  = Recoverable(errors.New("failure"))
+		action   = &mockAction{errors: []error{failure}}
+	)
+	ctx, cancelFunc := context.WithCancelCause(context.Background())
+	cancelFunc(shutdown)
+
+	err := Retry(ctx, action.Call, NewConstantBackoff(WithInterval(time.Hour)), RetryRecoverablePolicy)
+
+	assert.True(t, errors.Is(err, context.Canceled), err)
+	assert.True(t, errors.Is(err, shutdown), err)
+	assert.True(t, errors.Is(err, failure), err)
+	assert.Equal(t, "context canceled: shutting down, recover: failure", err.Error())
+}
@@ -0,0 +1,78 @@
+package recovererr
+
+import (
+	"context"
+	"errors"
+)
+
+// ContextError is returned by the retry mechanism when the context is done
+// before the failed function is retried.
+//
+// It unwraps to the error of the context, the cause of the context
+// cancellation, if provided, and the error of the last attempt.
+type ContextError struct {
+	// Ctx is the error of the context.
+	Ctx error
+	// Cause is the cause of the context cancellation, when it differs from the error of the context.
+	Cause error
+	// Err is the error of the last attempt.
+	Err error
+}
+
+func newContextError(ctx context.Context, err error) *ContextError {
+	ce := &ContextError{Ctx: ctx.Err(), Err: err}
+	if cause := contextCause(ctx); cause != nil && cause != ce.Ctx {
+		ce.Cause = cause
+	}
+	return ce
+}
+
+// Error returns the error in string format.
+func (ce *ContextError) Error() string {
+	msg := "<nil>"
+	if ce.Ctx != nil {
+		msg = ce.Ctx.Error()
+	}
+	if ce.Cause != nil {
+		msg += ": " + ce.Cause.Error()
+	}
+	return msg + ", " + ce.Err.Error()
+}
+
+// Is reports if the error of the context, the cause or the error of the last attempt
+// matches the target, since errors.Is ignores Unwrap() []error before Go 1.20.
+func (ce *ContextError) Is(target error) bool {
+	for _, err := range ce.Unwrap() {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error matching the target, in the error of the context, the cause
+// or the error of the last attempt, since errors.As ignores Unwrap() []error before Go 1.20.
+func (ce *ContextError) As(target interface{}) bool {
+	for _, err := range ce.Unwrap() {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unwrap provides the error of the context, the cause and the error of the last attempt.
+func (ce *ContextError) Unwrap() []error {
+	errs := make([]error, 0, 3)
+	for _, err := range []error{ce.Ctx, ce.Cause, ce.Err} {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// recoveryCause provides the error providing the recovery context.
+func (ce *ContextError) recoveryCause() error {
+	return ce.Err
+}
@@ -0,0 +1,65 @@
+package recovererr
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("deadline exceeded", func(t *testing.T) {
+		failure := Recoverable(errors.New("failure"))
+		action := &mockAction{errors: []error{failure}}
+		ctx, cancelFunc := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancelFunc()
+
+		err := Retry(ctx, action.Call, NewConstantBackoff(WithInterval(time.Hour)), RetryRecoverablePolicy)
+
+		var ce *ContextError
+		assert.True(t, errors.As(err, &ce), err)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded), err)
+		assert.True(t, errors.Is(err, failure), err)
+		assert.False(t, errors.Is(err, context.Canceled), err)
+		assert.Equal(t, "context deadline exceeded, recover: failure", err.Error())
+		found, recover := DoRecover(err)
+		assert.True(t, found)
+		assert.True(t, recover)
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		failure := errors.New("failure")
+		action := &mockAction{errors: []error{failure}}
+		ctx, cancelFunc := context.WithCancel(context.Background())
+		cancelFunc()
+		newBackoff := func() BackoffStrategy {
+			return NewConstantBackoff(WithInterval(time.Hour))
+		}
+
+		err := Do(ctx, action.Call, newBackoff, RetryNonUnrecoverablePolicy)
+
+		assert.True(t, errors.Is(err, context.Canceled), err)
+		assert.True(t, errors.Is(err, failure), err)
+		assert.Equal(t, []error{context.Canceled, failure}, err.(*ContextError).Unwrap())
+		found, _ := DoRecover(err)
+		assert.False(t, found)
+	})
+
+	t.Run("is and as without unwrapping multiple errors", func(t *testing.T) {
+		failure := &HTTPResponseError{StatusCode: 503}
+		ce := &ContextError{Ctx: context.Canceled, Err: failure}
+
+		assert.True(t, ce.Is(context.Canceled))
+		assert.True(t, ce.Is(failure))
+		assert.False(t, ce.Is(context.DeadlineExceeded))
+		var he *HTTPResponseError
+		assert.True(t, ce.As(&he))
+		assert.Equal(t, failure, he)
+		var re *RetryError
+		assert.False(t, ce.As(&re))
+	})
+}
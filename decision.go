@@ -0,0 +1,59 @@
+package recovererr
+
+import "time"
+
+type decisionKind int
+
+const (
+	decisionBackoff decisionKind = iota
+	decisionStop
+	decisionDelay
+	decisionNow
+	decisionReset
+)
+
+// Decision defines how the retry mechanism proceeds after a failed attempt.
+type Decision struct {
+	kind  decisionKind
+	delay time.Duration
+}
+
+var (
+	// RetryWithBackoff retries after the delay provided by the backoff strategy.
+	RetryWithBackoff = Decision{kind: decisionBackoff}
+	// Stop performs no retry.
+	Stop = Decision{kind: decisionStop}
+	// RetryNow retries without delay, leaving the backoff strategy untouched.
+	// The backoff strategy is not consulted, so a policy deciding RetryNow
+	// on every attempt retries until the context is done.
+	RetryNow = Decision{kind: decisionNow}
+	// ResetBackoff restarts the schedule of the backoff strategy and retries
+	// after the first delay it provides.
+	// The backoff strategy given to Retry or RetryAttempt is restarted only if it
+	// implements ResettableBackoffStrategy, and continues its schedule otherwise,
+	// while Do and DoAttempt create a new backoff strategy.
+	ResetBackoff = Decision{kind: decisionReset}
+)
+
+// RetryWithDelay retries after the given delay, overriding the delay
+// of the backoff strategy, which is left untouched.
+// The backoff strategy is not consulted, so a policy deciding RetryWithDelay
+// on every attempt retries until the context is done.
+func RetryWithDelay(d time.Duration) Decision {
+	return Decision{kind: decisionDelay, delay: d}
+}
+
+// DecisionPolicy function implements the policy deciding how to proceed
+// after a failed attempt.
+type DecisionPolicy func(err error, state RetryState) Decision
+
+// DecisionPolicyOf adapts a retry policy to a decision policy,
+// deciding RetryWithBackoff when the retry policy performs retry and Stop otherwise.
+func DecisionPolicyOf(policy RetryPolicy) DecisionPolicy {
+	return func(err error, _ RetryState) Decision {
+		if policy(err) {
+			return RetryWithBackoff
+		}
+		return Stop
+	}
+}
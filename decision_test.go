@@ -0,0 +1,103 @@
+package recovererr
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetry_decisionPolicy(t *testing.T) {
+	t.Parallel()
+
+	var (
+		errThrottled = errors.New("throttled")
+		errReset     = errors.New("connection reset")
+		errFatal     = errors.New("fatal")
+	)
+	policy := func(err error, state RetryState) Decision {
+		switch {
+		case errors.Is(err, errThrottled):
+			return RetryWithDelay(time.Minute)
+		case errors.Is(err, errReset):
+			return RetryNow
+		case errors.Is(err, errFatal):
+			return Stop
+		case state.Attempt.PreviousError != nil && errors.Is(state.Attempt.PreviousError, errThrottled):
+			return ResetBackoff
+		}
+		return RetryWithBackoff
+	}
+
+	tests := []struct {
+		name   string
+		errors []error
+		err    error
+		calls  int
+		delays []time.Duration
+		giveUp GiveUpReason
+	}{
+		{
+			name:   "retry with backoff",
+			errors: []error{errors.New("failure"), nil},
+			calls:  2,
+			delays: []time.Duration{time.Millisecond},
+		},
+		{
+			name:   "retry with delay",
+			errors: []error{errThrottled, nil},
+			calls:  2,
+			delays: []time.Duration{time.Minute},
+		},
+		{
+			name:   "retry now",
+			errors: []error{errReset, errReset, nil},
+			calls:  3,
+			delays: []time.Duration{0, 0},
+		},
+		{
+			name:   "stop",
+			errors: []error{errors.New("failure"), errFatal},
+			err:    errFatal,
+			calls:  2,
+			delays: []time.Duration{time.Millisecond},
+			giveUp: GiveUpPolicyStopped,
+		},
+		{
+			name:   "reset backoff",
+			errors: []error{errors.New("failure"), errThrottled, errors.New("failure"), errors.New("failure"), errors.New("failure")},
+			err:    errors.New("failure"),
+			calls:  5,
+			delays: []time.Duration{time.Millisecond, time.Minute, time.Millisecond, time.Millisecond},
+			giveUp: GiveUpBackoffExhausted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reasons []GiveUpReason
+			action := &mockAction{errors: tt.errors}
+			mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+			err := retry(context.Background(), action.Call, &mockClock, NewConstantBackoff(WithInterval(time.Millisecond), WithMaxAttempts(2)), RetryNonUnrecoverablePolicy,
+				WithDecisionPolicy(policy),
+				WithOnGiveUp(func(err error, reason GiveUpReason) { reasons = append(reasons, reason) }),
+			)
+
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.calls, action.callCounter)
+			assert.Equal(t, tt.delays, mockClock.delays)
+			if tt.giveUp != 0 {
+				assert.Equal(t, []GiveUpReason{tt.giveUp}, reasons)
+			}
+		})
+	}
+
+	t.Run("adapter of retry policy", func(t *testing.T) {
+		policy := DecisionPolicyOf(RetryRecoverablePolicy)
+
+		assert.Equal(t, RetryWithBackoff, policy(Recoverable(errReset), RetryState{}))
+		assert.Equal(t, Stop, policy(errReset, RetryState{}))
+	})
+}
@@ -0,0 +1,76 @@
+package recovererr
+
+import "time"
+
+// hooks holds the callbacks run synchronously by the retry loop.
+type hooks struct {
+	onAttempt []func(Attempt)
+	onRetry   []func(error, time.Duration)
+	onGiveUp  []func(error, GiveUpReason)
+	onSuccess []func(int)
+	// onWait receives the time actually waited before a retry or its cancellation.
+	onWait []func(time.Duration)
+}
+
+// WithOnAttempt registers a callback run before each attempt.
+func WithOnAttempt(f func(a Attempt)) RetryOption {
+	return func(ro *retryOptions) {
+		ro.hooks.onAttempt = append(ro.hooks.onAttempt, f)
+	}
+}
+
+// WithOnRetry registers a callback run after a failed attempt,
+// before waiting for the given delay to retry.
+// It is not run when the context is already done, while the retry is still
+// cancelled if the context is done during the wait.
+func WithOnRetry(f func(err error, delay time.Duration)) RetryOption {
+	return func(ro *retryOptions) {
+		ro.hooks.onRetry = append(ro.hooks.onRetry, f)
+	}
+}
+
+// WithOnGiveUp registers a callback run when retry gives up,
+// receiving the returned error and the give up reason.
+func WithOnGiveUp(f func(err error, reason GiveUpReason)) RetryOption {
+	return func(ro *retryOptions) {
+		ro.hooks.onGiveUp = append(ro.hooks.onGiveUp, f)
+	}
+}
+
+// WithOnSuccess registers a callback run when retry returns no error,
+// receiving the number of attempts performed.
+func WithOnSuccess(f func(attempts int)) RetryOption {
+	return func(ro *retryOptions) {
+		ro.hooks.onSuccess = append(ro.hooks.onSuccess, f)
+	}
+}
+
+func (h *hooks) attempt(a Attempt) {
+	for _, f := range h.onAttempt {
+		f(a)
+	}
+}
+
+func (h *hooks) retry(err error, delay time.Duration) {
+	for _, f := range h.onRetry {
+		f(err, delay)
+	}
+}
+
+func (h *hooks) wait(waited time.Duration) {
+	for _, f := range h.onWait {
+		f(waited)
+	}
+}
+
+func (h *hooks) giveUp(err error, reason GiveUpReason) {
+	for _, f := range h.onGiveUp {
+		f(err, reason)
+	}
+}
+
+func (h *hooks) success(attempts int) {
+	for _, f := range h.onSuccess {
+		f(attempts)
+	}
+}
@@ -0,0 +1,113 @@
+package recovererr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetry_hooks(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success after retry", func(t *testing.T) {
+		var (
+			events  []string
+			failure = Recoverable(errors.New("failure"))
+			action  = &mockAction{errors: []error{failure, nil}}
+		)
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		err := retry(context.Background(), action.Call, &mockClock, NewConstantBackoff(WithInterval(time.Millisecond)), RetryRecoverablePolicy,
+			WithOnAttempt(func(a Attempt) {
+				events = append(events, fmt.Sprintf("attempt %d", a.Number))
+			}),
+			WithOnRetry(func(err error, delay time.Duration) {
+				_, recover := DoRecover(err)
+				events = append(events, fmt.Sprintf("retry %v after %s, recover %t", err, delay, recover))
+			}),
+			WithOnGiveUp(func(err error, reason GiveUpReason) {
+				events = append(events, "give up")
+			}),
+			WithOnSuccess(func(attempts int) {
+				events = append(events, fmt.Sprintf("success after %d attempt(s)", attempts))
+			}),
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"attempt 1",
+			"retry recover: failure after 1ms, recover true",
+			"attempt 2",
+			"success after 2 attempt(s)",
+		}, events)
+	})
+
+	t.Run("give up", func(t *testing.T) {
+		var (
+			reasons   []GiveUpReason
+			errs      []error
+			successes int
+			failure   = Recoverable(errors.New("failure"))
+			action    = &mockAction{errors: []error{failure}}
+		)
+		newBackoff := func() BackoffStrategy {
+			return NewConstantBackoff(WithInterval(time.Millisecond), WithMaxAttempts(2))
+		}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		err := do(context.Background(), action.Call, &mockClock, newBackoff, RetryRecoverablePolicy,
+			WithOnGiveUp(func(err error, reason GiveUpReason) {
+				errs = append(errs, err)
+				reasons = append(reasons, reason)
+			}),
+			WithOnSuccess(func(int) {
+				successes++
+			}),
+		)
+
+		assert.Equal(t, failure, err)
+		assert.Equal(t, []error{failure}, errs)
+		assert.Equal(t, []GiveUpReason{GiveUpBackoffExhausted}, reasons)
+		assert.Equal(t, 0, successes)
+	})
+
+	t.Run("no retry once the context is done", func(t *testing.T) {
+		var (
+			retries int
+			reasons []GiveUpReason
+			action  = &mockAction{errors: []error{Recoverable(errors.New("failure"))}}
+		)
+		ctx, cancelFunc := context.WithCancel(context.Background())
+		cancelFunc()
+
+		err := Retry(ctx, action.Call, NewConstantBackoff(WithInterval(time.Second)), RetryRecoverablePolicy,
+			WithOnRetry(func(err error, delay time.Duration) {
+				retries++
+			}),
+			WithOnGiveUp(func(err error, reason GiveUpReason) {
+				reasons = append(reasons, reason)
+			}),
+		)
+
+		assert.True(t, errors.Is(err, context.Canceled), err)
+		assert.Equal(t, 0, retries)
+		assert.Equal(t, []GiveUpReason{GiveUpContextDone}, reasons)
+	})
+
+	t.Run("multiple hooks", func(t *testing.T) {
+		var calls []int
+		action := &mockAction{}
+
+		err := Retry(context.Background(), action.Call, NewConstantBackoff(), RetryRecoverablePolicy,
+			WithOnSuccess(func(attempts int) { calls = append(calls, 1) }),
+			WithOnSuccess(func(attempts int) { calls = append(calls, 2) }),
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1, 2}, calls)
+	})
+}
@@ -0,0 +1,94 @@
+package recovererr
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HTTPResponseError describes a failed HTTP response.
+type HTTPResponseError struct {
+	StatusCode int
+	Status     string
+	Method     string
+	URL        string
+}
+
+// Error returns the error in string format.
+func (he *HTTPResponseError) Error() string {
+	sb := strings.Builder{}
+	if he.Method != "" {
+		sb.WriteString(he.Method)
+		sb.WriteString(" ")
+	}
+	if he.URL != "" {
+		sb.WriteString(he.URL)
+		sb.WriteString(": ")
+	}
+	sb.WriteString(he.Status)
+	return sb.String()
+}
+
+// FromHTTPResponse provides the recovery context of an HTTP response.
+//
+// Returns:
+// 1. nil for non-error status codes
+// 2. recoverable error for status codes 408, 425, 429, 500, 502, 503 and 504,
+// hinting the delay provided by the `Retry-After` header
+// 3. unrecoverable error for any other status code
+//
+// The options are applied to the returned error, e.g. WithDependency.
+func FromHTTPResponse(resp *http.Response, opts ...ErrorOption) error {
+	if resp == nil {
+		panic("recoverror: response cannot be nil")
+	}
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	he := &HTTPResponseError{StatusCode: resp.StatusCode, Status: resp.Status}
+	if he.Status == "" {
+		he.Status = fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	if resp.Request != nil {
+		he.Method = resp.Request.Method
+		if resp.Request.URL != nil {
+			he.URL = resp.Request.URL.Redacted()
+		}
+	}
+
+	re := &recoveryError{err: he}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		re = withRetryAfterHeader(&recoveryError{err: he, recover: true, class: ClassThrottled}, resp.Header)
+	case http.StatusRequestTimeout,
+		http.StatusTooEarly,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		re = withRetryAfterHeader(&recoveryError{err: he, recover: true}, resp.Header)
+	}
+	return newRecoveryError(re, opts)
+}
+
+// withRetryAfterHeader sets the retry delay hint parsed from the `Retry-After` header,
+// in either delta-seconds or HTTP-date form.
+func withRetryAfterHeader(re *recoveryError, header http.Header) *recoveryError {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return re
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds > 0 {
+			re.after = time.Duration(seconds) * time.Second
+		}
+		return re
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		re.at = t
+	}
+	return re
+}
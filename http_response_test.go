@@ -0,0 +1,101 @@
+package recovererr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromHTTPResponse(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if retryAfter := r.URL.Query().Get("retry_after"); retryAfter != "" {
+			w.Header().Set("Retry-After", retryAfter)
+		}
+		code, _ := strconv.Atoi(r.URL.Query().Get("code"))
+		w.WriteHeader(code)
+	}))
+	defer server.Close()
+
+	get := func(t *testing.T, query string, opts ...ErrorOption) error {
+		resp, err := http.Get(server.URL + "/?" + query)
+		assert.Nil(t, err)
+		defer resp.Body.Close()
+
+		return FromHTTPResponse(resp, opts...)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		assert.Nil(t, get(t, "code=200"))
+		assert.Nil(t, get(t, "code=304"))
+	})
+
+	t.Run("recoverable status codes", func(t *testing.T) {
+		for _, code := range []int{408, 425, 429, 500, 502, 503, 504} {
+			found, recover := DoRecover(get(t, "code="+strconv.Itoa(code)))
+			assert.True(t, found, code)
+			assert.True(t, recover, code)
+		}
+	})
+
+	t.Run("unrecoverable status codes", func(t *testing.T) {
+		for _, code := range []int{400, 401, 403, 404, 409, 422, 501} {
+			found, recover := DoRecover(get(t, "code="+strconv.Itoa(code)))
+			assert.True(t, found, code)
+			assert.False(t, recover, code)
+		}
+	})
+
+	t.Run("throttled class", func(t *testing.T) {
+		_, class := DoClassify(get(t, "code=429"))
+		assert.Equal(t, ClassThrottled, class)
+	})
+
+	t.Run("response error", func(t *testing.T) {
+		err := get(t, "code=503")
+
+		var he *HTTPResponseError
+		assert.True(t, errors.As(err, &he))
+		assert.Equal(t, http.StatusServiceUnavailable, he.StatusCode)
+		assert.Equal(t, "recover: GET "+server.URL+"/?code=503: 503 Service Unavailable", err.Error())
+	})
+
+	t.Run("error options", func(t *testing.T) {
+		err := get(t, "code=429&retry_after=120", WithDependency("billing"), WithStack())
+
+		dependency, ok := Dependency(err)
+		assert.True(t, ok)
+		assert.Equal(t, "billing", dependency)
+		assert.Contains(t, fmt.Sprintf("%+v", err), "TestFromHTTPResponse")
+
+		d, ok := RetryAfter(err)
+		assert.True(t, ok)
+		assert.Equal(t, 2*time.Minute, d)
+	})
+
+	t.Run("retry after delta seconds", func(t *testing.T) {
+		d, ok := RetryAfter(get(t, "code=429&retry_after=120"))
+		assert.True(t, ok)
+		assert.Equal(t, 2*time.Minute, d)
+	})
+
+	t.Run("retry after http date", func(t *testing.T) {
+		at := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+		d, ok := RetryAfter(get(t, "code=503&retry_after="+url.QueryEscape(at)))
+		assert.True(t, ok)
+		assert.True(t, d > 58*time.Minute && d <= time.Hour, d)
+	})
+
+	t.Run("invalid retry after", func(t *testing.T) {
+		_, ok := RetryAfter(get(t, "code=503&retry_after=soon"))
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,138 @@
+package recovererr
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// Logger logs structured records of retry activity.
+type Logger interface {
+	Log(msg string, attrs ...Attr)
+}
+
+// LoggerFunc adapts a function to the Logger interface.
+type LoggerFunc func(msg string, attrs ...Attr)
+
+// Log implements the Logger.Log method.
+func (lf LoggerFunc) Log(msg string, attrs ...Attr) {
+	lf(msg, attrs...)
+}
+
+// StdLogger adapts a log.Logger to the Logger interface,
+// printing records in `msg key=value` format.
+func StdLogger(l *log.Logger) Logger {
+	return LoggerFunc(func(msg string, attrs ...Attr) {
+		sb := strings.Builder{}
+		sb.WriteString(msg)
+		for _, attr := range attrs {
+			sb.WriteString(" ")
+			sb.WriteString(attr.Key)
+			sb.WriteString("=")
+			switch v := attr.Value.(type) {
+			case string:
+				sb.WriteString(fmt.Sprintf("%q", v))
+			case error:
+				sb.WriteString(fmt.Sprintf("%q", v.Error()))
+			default:
+				sb.WriteString(fmt.Sprint(v))
+			}
+		}
+		l.Print(sb.String())
+	})
+}
+
+const (
+	defaultLogBurst = 10
+	defaultLogEvery = 100
+)
+
+// WithLogger configures retry to log a record per retry and a record when giving up.
+//
+// After the first 10 retries of a call, retries are summarised by a single
+// record per 100 retries.
+func WithLogger(l Logger) RetryOption {
+	return WithRateLimitedLogger(l, defaultLogBurst, defaultLogEvery)
+}
+
+// WithRateLimitedLogger works like WithLogger, logging a record for each of
+// the first burst retries of a call and a summary record per every retries after that.
+// The remaining suppressed retries are summarised when retry gives up or succeeds.
+func WithRateLimitedLogger(l Logger, burst, every int) RetryOption {
+	return func(ro *retryOptions) {
+		rl := &retryLogger{logger: l, burst: burst, every: every}
+		ro.hooks.onAttempt = append(ro.hooks.onAttempt, rl.attempt)
+		ro.hooks.onRetry = append(ro.hooks.onRetry, rl.retry)
+		ro.hooks.onGiveUp = append(ro.hooks.onGiveUp, rl.giveUp)
+		ro.hooks.onSuccess = append(ro.hooks.onSuccess, rl.success)
+	}
+}
+
+// retryLogger logs the retry activity of a single call.
+type retryLogger struct {
+	logger       Logger
+	burst, every int
+
+	attemptNumber int
+	retries       int
+	suppressed    int
+	waited        time.Duration
+	lastErr       error
+}
+
+func (rl *retryLogger) attempt(a Attempt) {
+	rl.attemptNumber = a.Number
+}
+
+func (rl *retryLogger) retry(err error, delay time.Duration) {
+	rl.retries++
+	if rl.retries <= rl.burst {
+		rl.logger.Log("retry", retryAttrs(rl.attemptNumber, err, Attr{Key: "delay", Value: delay})...)
+		return
+	}
+
+	rl.suppressed++
+	rl.waited += delay
+	rl.lastErr = err
+	if rl.every > 0 && rl.suppressed >= rl.every {
+		rl.summarise(err)
+	}
+}
+
+func (rl *retryLogger) giveUp(err error, reason GiveUpReason) {
+	if rl.suppressed > 0 {
+		rl.summarise(err)
+	}
+	rl.logger.Log("give up", retryAttrs(rl.attemptNumber, err, Attr{Key: "reason", Value: reason.String()})...)
+}
+
+// success logs the summary of the retries suppressed before the successful attempt.
+func (rl *retryLogger) success(int) {
+	if rl.suppressed > 0 {
+		rl.summarise(rl.lastErr)
+	}
+}
+
+func (rl *retryLogger) summarise(err error) {
+	rl.logger.Log("retry summary", retryAttrs(rl.attemptNumber, err,
+		Attr{Key: "retries", Value: rl.suppressed},
+		Attr{Key: "waited", Value: rl.waited},
+	)...)
+	rl.suppressed, rl.waited = 0, 0
+}
+
+// retryAttrs provides the attributes of a record, including the recovery context of the error.
+func retryAttrs(attempt int, err error, attrs ...Attr) []Attr {
+	result := []Attr{{Key: "attempt", Value: attempt}}
+	result = append(result, attrs...)
+	_, recover := DoRecover(err)
+	result = append(result, Attr{Key: "recover", Value: recover})
+	if found, class := DoClassify(err); found {
+		result = append(result, Attr{Key: "class", Value: class.String()})
+	}
+	if err != nil {
+		result = append(result, Attr{Key: "error", Value: err})
+	}
+	return result
+}
@@ -0,0 +1,20 @@
+//go:build go1.21
+
+package recovererr
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogLogger adapts a slog.Logger to the Logger interface,
+// logging records at the given level.
+func SlogLogger(l *slog.Logger, level slog.Level) Logger {
+	return LoggerFunc(func(msg string, attrs ...Attr) {
+		args := make([]slog.Attr, 0, len(attrs))
+		for _, attr := range attrs {
+			args = append(args, slog.Any(attr.Key, attr.Value))
+		}
+		l.LogAttrs(context.Background(), level, msg, args...)
+	})
+}
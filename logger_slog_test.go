@@ -0,0 +1,37 @@
+//go:build go1.21
+
+package recovererr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlogLogger(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	action := &mockAction{errors: []error{Recoverable(errors.New("failure")), nil}}
+	mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+	_ = retry(context.Background(), action.Call, &mockClock, NewConstantBackoff(WithInterval(time.Millisecond)), RetryRecoverablePolicy,
+		WithLogger(SlogLogger(slog.New(handler), slog.LevelWarn)),
+	)
+
+	assert.Equal(t, `level=WARN msg=retry attempt=1 delay=1ms recover=true class=transient error="recover: failure"`, strings.TrimSpace(buf.String()))
+}
@@ -0,0 +1,116 @@
+package recovererr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetry_logger(t *testing.T) {
+	t.Parallel()
+
+	t.Run("record per retry", func(t *testing.T) {
+		var buf bytes.Buffer
+		action := &mockAction{errors: []error{Classified(errors.New("too many requests"), ClassThrottled)}}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		_ = retry(context.Background(), action.Call, &mockClock, NewConstantBackoff(WithInterval(time.Millisecond), WithMaxAttempts(2)), RetryRecoverablePolicy,
+			WithLogger(StdLogger(log.New(&buf, "", 0))),
+		)
+
+		assert.Equal(t, strings.Join([]string{
+			`retry attempt=1 delay=1ms recover=true class="throttled" error="recover: too many requests"`,
+			`retry attempt=2 delay=1ms recover=true class="throttled" error="recover: too many requests"`,
+			`give up attempt=3 reason="backoff exhausted" recover=true class="throttled" error="recover: too many requests"`,
+			``,
+		}, "\n"), buf.String())
+	})
+
+	t.Run("rate limited summaries", func(t *testing.T) {
+		var records []string
+		logger := LoggerFunc(func(msg string, attrs ...Attr) {
+			record := msg
+			for _, attr := range attrs {
+				if attr.Key == "attempt" || attr.Key == "retries" || attr.Key == "waited" {
+					record += fmt.Sprintf(" %s=%v", attr.Key, attr.Value)
+				}
+			}
+			records = append(records, record)
+		})
+		action := &mockAction{errors: []error{errors.New("failure")}}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		_ = retry(context.Background(), action.Call, &mockClock, NewConstantBackoff(WithInterval(time.Millisecond), WithMaxAttempts(250)), RetryNonUnrecoverablePolicy,
+			WithRateLimitedLogger(logger, 2, 100),
+		)
+
+		assert.Equal(t, []string{
+			"retry attempt=1",
+			"retry attempt=2",
+			"retry summary attempt=102 retries=100 waited=100ms",
+			"retry summary attempt=202 retries=100 waited=100ms",
+			"retry summary attempt=251 retries=48 waited=48ms",
+			"give up attempt=251",
+		}, records)
+	})
+
+	t.Run("summary on success", func(t *testing.T) {
+		var records []string
+		logger := LoggerFunc(func(msg string, attrs ...Attr) {
+			record := msg
+			for _, attr := range attrs {
+				if attr.Key == "attempt" || attr.Key == "retries" {
+					record += fmt.Sprintf(" %s=%v", attr.Key, attr.Value)
+				}
+			}
+			records = append(records, record)
+		})
+		errs := make([]error, 0, 50)
+		for i := 0; i < 49; i++ {
+			errs = append(errs, errors.New("failure"))
+		}
+		action := &mockAction{errors: append(errs, nil)}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		err := retry(context.Background(), action.Call, &mockClock, NewConstantBackoff(WithInterval(time.Millisecond), WithMaxAttempts(100)), RetryNonUnrecoverablePolicy,
+			WithRateLimitedLogger(logger, 2, 100),
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"retry attempt=1",
+			"retry attempt=2",
+			"retry summary attempt=50 retries=47",
+		}, records)
+	})
+
+	t.Run("logger state per call", func(t *testing.T) {
+		var retries int
+		logger := LoggerFunc(func(msg string, attrs ...Attr) {
+			if msg == "retry" {
+				retries++
+			}
+		})
+		r := NewRetrier(
+			WithBackoffStrategy(func() BackoffStrategy {
+				return NewConstantBackoff(WithInterval(time.Millisecond), WithMaxAttempts(2))
+			}),
+			WithRetrierClock(&mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}),
+			WithRetryOptions(WithRateLimitedLogger(logger, 2, 100)),
+		)
+
+		for i := 0; i < 3; i++ {
+			action := &mockAction{errors: []error{Recoverable(errors.New("failure"))}}
+			_ = r.Do(context.Background(), action.Call)
+		}
+
+		assert.Equal(t, 6, retries)
+	})
+}
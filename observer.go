@@ -0,0 +1,204 @@
+package recovererr
+
+import (
+	"encoding/json"
+	"expvar"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Observer observes retry activity, labelled by the operation name
+// configured by WithOperation.
+//
+// The total wait time is the time actually spent waiting between attempts,
+// including a wait cut short by the context.
+type Observer interface {
+	// ObserveAttempt is called before each attempt.
+	ObserveAttempt(operation string)
+	// ObserveRetry is called before waiting for the given delay to retry.
+	ObserveRetry(operation string, delay time.Duration)
+	// ObserveGiveUp is called when retry gives up, after the given attempts and total wait time.
+	ObserveGiveUp(operation string, reason GiveUpReason, attempts int, waited time.Duration)
+	// ObserveSuccess is called when retry returns no error, after the given attempts and total wait time.
+	ObserveSuccess(operation string, attempts int, waited time.Duration)
+}
+
+// defaultOperation labels the activity of calls configured with no operation name.
+const defaultOperation = "default"
+
+// WithOperation configures the operation name labelling the activity
+// reported to the observers and traces.
+func WithOperation(name string) RetryOption {
+	return func(ro *retryOptions) {
+		ro.operation = name
+	}
+}
+
+// operationName provides the configured operation name, or the default one.
+func (ro *retryOptions) operationName() string {
+	if ro.operation == "" {
+		return defaultOperation
+	}
+	return ro.operation
+}
+
+// WithObserver configures retry to report its activity to the given observer.
+func WithObserver(o Observer) RetryOption {
+	return func(ro *retryOptions) {
+		var (
+			attempts int
+			waited   time.Duration
+		)
+		ro.hooks.onAttempt = append(ro.hooks.onAttempt, func(a Attempt) {
+			attempts = a.Number
+			o.ObserveAttempt(ro.operationName())
+		})
+		ro.hooks.onRetry = append(ro.hooks.onRetry, func(err error, delay time.Duration) {
+			o.ObserveRetry(ro.operationName(), delay)
+		})
+		ro.hooks.onWait = append(ro.hooks.onWait, func(d time.Duration) {
+			waited += d
+		})
+		ro.hooks.onGiveUp = append(ro.hooks.onGiveUp, func(err error, reason GiveUpReason) {
+			o.ObserveGiveUp(ro.operationName(), reason, attempts, waited)
+		})
+		ro.hooks.onSuccess = append(ro.hooks.onSuccess, func(int) {
+			o.ObserveSuccess(ro.operationName(), attempts, waited)
+		})
+	}
+}
+
+// MetricsObserver is an in-memory observer collecting counters and histograms
+// per operation. It implements expvar.Var, to be published on `/debug/vars`.
+type MetricsObserver struct {
+	mu  sync.Mutex
+	ops expvar.Map
+}
+
+// NewMetricsObserver creates new in-memory metrics observer.
+func NewMetricsObserver() *MetricsObserver {
+	mo := MetricsObserver{}
+	mo.ops.Init()
+	return &mo
+}
+
+// PublishMetricsObserver creates new in-memory metrics observer,
+// published to expvar using the given name.
+// It panics if the name is already published, like expvar.Publish.
+func PublishMetricsObserver(name string) *MetricsObserver {
+	mo := NewMetricsObserver()
+	expvar.Publish(name, mo)
+	return mo
+}
+
+// String implements the expvar.Var.String method.
+func (mo *MetricsObserver) String() string {
+	return mo.ops.String()
+}
+
+// Operation provides the metrics of the given operation, if observed.
+func (mo *MetricsObserver) Operation(name string) (*expvar.Map, bool) {
+	m, ok := mo.ops.Get(name).(*expvar.Map)
+	return m, ok
+}
+
+// ObserveAttempt implements the Observer.ObserveAttempt method.
+func (mo *MetricsObserver) ObserveAttempt(operation string) {
+	mo.operation(operation).Add("attempts", 1)
+}
+
+// ObserveRetry implements the Observer.ObserveRetry method.
+func (mo *MetricsObserver) ObserveRetry(operation string, delay time.Duration) {
+	mo.operation(operation).Add("retries", 1)
+}
+
+// ObserveGiveUp implements the Observer.ObserveGiveUp method.
+func (mo *MetricsObserver) ObserveGiveUp(operation string, reason GiveUpReason, attempts int, waited time.Duration) {
+	m := mo.operation(operation)
+	m.Get("give_ups").(*expvar.Map).Add(reason.String(), 1)
+	mo.observeCall(m, attempts, waited)
+}
+
+// ObserveSuccess implements the Observer.ObserveSuccess method.
+func (mo *MetricsObserver) ObserveSuccess(operation string, attempts int, waited time.Duration) {
+	m := mo.operation(operation)
+	m.Add("successes", 1)
+	if attempts > 1 {
+		m.Add("successes_after_retry", 1)
+	}
+	mo.observeCall(m, attempts, waited)
+}
+
+func (mo *MetricsObserver) observeCall(m *expvar.Map, attempts int, waited time.Duration) {
+	m.Get("attempts_per_call").(*histogram).observe(float64(attempts))
+	m.Get("wait_seconds").(*histogram).observe(waited.Seconds())
+}
+
+// operation provides the metrics of the given operation, creating them if missing.
+func (mo *MetricsObserver) operation(name string) *expvar.Map {
+	if m, ok := mo.Operation(name); ok {
+		return m
+	}
+
+	mo.mu.Lock()
+	defer mo.mu.Unlock()
+
+	if m, ok := mo.Operation(name); ok {
+		return m
+	}
+	m := new(expvar.Map).Init()
+	for _, key := range []string{"attempts", "retries", "successes", "successes_after_retry"} {
+		m.Set(key, new(expvar.Int))
+	}
+	m.Set("give_ups", new(expvar.Map).Init())
+	m.Set("attempts_per_call", newHistogram(1, 2, 3, 5, 10, 20, 50, 100))
+	m.Set("wait_seconds", newHistogram(0.01, 0.1, 1, 5, 10, 30, 60, 300))
+	mo.ops.Set(name, m)
+	return m
+}
+
+// histogram is a cumulative histogram implementing expvar.Var.
+type histogram struct {
+	mu      sync.Mutex
+	bounds  []float64
+	buckets []int64
+	count   int64
+	sum     float64
+}
+
+func newHistogram(bounds ...float64) *histogram {
+	return &histogram{bounds: bounds, buckets: make([]int64, len(bounds))}
+}
+
+func (h *histogram) observe(v float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.count++
+	h.sum += v
+	for i, bound := range h.bounds {
+		if v <= bound {
+			h.buckets[i]++
+		}
+	}
+}
+
+// String implements the expvar.Var.String method.
+func (h *histogram) String() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	buckets := make(map[string]int64, len(h.bounds)+1)
+	for i, bound := range h.bounds {
+		buckets[strconv.FormatFloat(bound, 'g', -1, 64)] = h.buckets[i]
+	}
+	buckets["+Inf"] = h.count
+
+	b, _ := json.Marshal(struct {
+		Count   int64            `json:"count"`
+		Sum     float64          `json:"sum"`
+		Buckets map[string]int64 `json:"buckets"`
+	}{h.count, h.sum, buckets})
+	return string(b)
+}
@@ -0,0 +1,110 @@
+package recovererr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetry_observer(t *testing.T) {
+	t.Parallel()
+
+	mo := NewMetricsObserver()
+	mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+	succeeding := &mockAction{errors: []error{errors.New("failure"), errors.New("failure"), nil}}
+	err := retry(context.Background(), succeeding.Call, &mockClock, NewConstantBackoff(WithInterval(time.Second), WithMaxAttempts(5)), RetryNonUnrecoverablePolicy,
+		WithObserver(mo), WithOperation("fetch"),
+	)
+	assert.NoError(t, err)
+
+	failing := &mockAction{errors: []error{errors.New("failure")}}
+	err = retry(context.Background(), failing.Call, &mockClock, NewConstantBackoff(WithInterval(time.Second), WithMaxAttempts(1)), RetryNonUnrecoverablePolicy,
+		WithOperation("fetch"), WithObserver(mo),
+	)
+	assert.Error(t, err)
+
+	unrecoverable := &mockAction{errors: []error{Unrecoverable(errors.New("failure"))}}
+	err = retry(context.Background(), unrecoverable.Call, &mockClock, NewConstantBackoff(WithInterval(time.Second)), RetryNonUnrecoverablePolicy,
+		WithObserver(mo),
+	)
+	assert.Error(t, err)
+
+	var fetch struct {
+		Attempts            int64          `json:"attempts"`
+		Retries             int64          `json:"retries"`
+		Successes           int64          `json:"successes"`
+		SuccessesAfterRetry int64          `json:"successes_after_retry"`
+		GiveUps             map[string]int `json:"give_ups"`
+		AttemptsPerCall     struct {
+			Count   int64            `json:"count"`
+			Sum     float64          `json:"sum"`
+			Buckets map[string]int64 `json:"buckets"`
+		} `json:"attempts_per_call"`
+		WaitSeconds struct {
+			Count int64   `json:"count"`
+			Sum   float64 `json:"sum"`
+		} `json:"wait_seconds"`
+	}
+	m, found := mo.Operation("fetch")
+	assert.True(t, found)
+	assert.NoError(t, json.Unmarshal([]byte(m.String()), &fetch))
+
+	assert.Equal(t, int64(5), fetch.Attempts)
+	assert.Equal(t, int64(3), fetch.Retries)
+	assert.Equal(t, int64(1), fetch.Successes)
+	assert.Equal(t, int64(1), fetch.SuccessesAfterRetry)
+	assert.Equal(t, map[string]int{GiveUpBackoffExhausted.String(): 1}, fetch.GiveUps)
+	assert.Equal(t, int64(2), fetch.AttemptsPerCall.Count)
+	assert.Equal(t, float64(5), fetch.AttemptsPerCall.Sum)
+	assert.Equal(t, map[string]int64{"1": 0, "2": 1, "3": 2, "5": 2, "10": 2, "20": 2, "50": 2, "100": 2, "+Inf": 2}, fetch.AttemptsPerCall.Buckets)
+	assert.Equal(t, int64(2), fetch.WaitSeconds.Count)
+	assert.True(t, fetch.WaitSeconds.Sum < 1, "planned delays of the mock clock are not waited: %v", fetch.WaitSeconds.Sum)
+
+	_, found = mo.Operation(defaultOperation)
+	assert.True(t, found)
+	_, found = mo.Operation("unknown")
+	assert.False(t, found)
+}
+
+func TestRetry_observerWaitCancelled(t *testing.T) {
+	t.Parallel()
+
+	mo := NewMetricsObserver()
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancelFunc()
+	action := &mockAction{errors: []error{errors.New("failure")}}
+
+	err := Retry(ctx, action.Call, NewConstantBackoff(WithInterval(time.Hour)), RetryNonUnrecoverablePolicy, WithObserver(mo))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), err)
+
+	var metrics struct {
+		Retries     int64 `json:"retries"`
+		WaitSeconds struct {
+			Sum float64 `json:"sum"`
+		} `json:"wait_seconds"`
+	}
+	m, _ := mo.Operation(defaultOperation)
+	assert.NoError(t, json.Unmarshal([]byte(m.String()), &metrics))
+
+	assert.Equal(t, int64(1), metrics.Retries)
+	assert.True(t, metrics.WaitSeconds.Sum > 0.01 && metrics.WaitSeconds.Sum < 1, metrics.WaitSeconds.Sum)
+}
+
+func TestPublishMetricsObserver(t *testing.T) {
+	t.Parallel()
+
+	// expvar names cannot be reused, e.g. by go test -count
+	name := "recovererr_test_" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	mo := PublishMetricsObserver(name)
+	mo.ObserveAttempt("fetch")
+
+	assert.Equal(t, mo, expvar.Get(name))
+	assert.JSONEq(t, mo.String(), expvar.Get(name).String())
+}
@@ -0,0 +1,49 @@
+package recovererr
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError describes a panic recovered from a function.
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+// Error returns the error in string format.
+func (pe *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", pe.Value)
+}
+
+// Unwrap provides the panic value, when it is an error.
+func (pe *PanicError) Unwrap() error {
+	if err, ok := pe.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// Safe wraps a function to return a *PanicError when it panics.
+func Safe(f func() error) func() error {
+	return func() (err error) {
+		defer func() {
+			if v := recover(); v != nil {
+				err = &PanicError{Value: v, Stack: debug.Stack()}
+			}
+		}()
+		return f()
+	}
+}
+
+// safeClassified wraps a function to return a *PanicError of the given class when it panics.
+func safeClassified(f func() error, class Class) func() error {
+	f = Safe(f)
+	return func() error {
+		err := f()
+		if _, ok := err.(*PanicError); ok {
+			return Classified(err, class)
+		}
+		return err
+	}
+}
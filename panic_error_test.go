@@ -0,0 +1,78 @@
+package recovererr
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSafe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("panic value", func(t *testing.T) {
+		err := Safe(func() error { panic("decoder failure") })()
+
+		var pe *PanicError
+		assert.True(t, errors.As(err, &pe))
+		assert.Equal(t, "decoder failure", pe.Value)
+		assert.NotEmpty(t, pe.Stack)
+		assert.Equal(t, "panic: decoder failure", err.Error())
+		assert.Nil(t, errors.Unwrap(err))
+	})
+
+	t.Run("panic error", func(t *testing.T) {
+		cause := errors.New("decoder failure")
+		err := Safe(func() error { panic(cause) })()
+
+		assert.True(t, errors.Is(err, cause))
+	})
+
+	t.Run("no panic", func(t *testing.T) {
+		cause := errors.New("failure")
+
+		assert.Equal(t, cause, Safe(func() error { return cause })())
+		assert.Nil(t, Safe(func() error { return nil })())
+	})
+}
+
+func TestRetry_panicRecovery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("retry recovered panic", func(t *testing.T) {
+		action := &mockAction{errors: []error{nil}}
+		panics := 2
+		f := func() error {
+			if panics > 0 {
+				panics--
+				panic("decoder failure")
+			}
+			return action.Call()
+		}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		err := retry(context.Background(), f, &mockClock, NewConstantBackoff(WithInterval(time.Millisecond)), RetryRecoverablePolicy, WithPanicRecovery(ClassTransient))
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, action.callCounter)
+		assert.Len(t, mockClock.delays, 2)
+	})
+
+	t.Run("unrecoverable recovered panic", func(t *testing.T) {
+		f := func() error { panic("decoder failure") }
+		newBackoff := func() BackoffStrategy {
+			return NewConstantBackoff(WithInterval(time.Millisecond))
+		}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		err := do(context.Background(), f, &mockClock, newBackoff, RetryNonUnrecoverablePolicy, WithPanicRecovery(ClassPermanent))
+
+		var pe *PanicError
+		assert.True(t, errors.As(err, &pe))
+		_, class := DoClassify(err)
+		assert.Equal(t, ClassPermanent, class)
+		assert.Empty(t, mockClock.delays)
+	})
+}
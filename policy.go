@@ -0,0 +1,143 @@
+package recovererr
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// And creates a retry policy performing retry when all the given policies do.
+func And(policies ...RetryPolicy) RetryPolicy {
+	return func(err error) bool {
+		for _, p := range policies {
+			if !p(err) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or creates a retry policy performing retry when any of the given policies does.
+func Or(policies ...RetryPolicy) RetryPolicy {
+	return func(err error) bool {
+		for _, p := range policies {
+			if p(err) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not creates a retry policy performing retry when the given policy does not.
+func Not(policy RetryPolicy) RetryPolicy {
+	return func(err error) bool {
+		return !policy(err)
+	}
+}
+
+// IfErrorIs creates a retry policy performing retry when the error matches
+// any of the given targets, using errors.Is.
+func IfErrorIs(targets ...error) RetryPolicy {
+	return func(err error) bool {
+		if err == nil {
+			return false
+		}
+		for _, target := range targets {
+			if errors.Is(err, target) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// IfErrorAs creates a retry policy performing retry when the error chain
+// contains an error of type T, using errors.As.
+func IfErrorAs[T error]() RetryPolicy {
+	return func(err error) bool {
+		var target T
+		return err != nil && errors.As(err, &target)
+	}
+}
+
+// ExceptErrorIs creates a retry policy performing no retry when the error
+// matches any of the given targets, using errors.Is.
+// It is meant to be combined with other policies using And.
+func ExceptErrorIs(targets ...error) RetryPolicy {
+	return Not(IfErrorIs(targets...))
+}
+
+// LimitFor creates a retry policy performing no retry after the matcher
+// has matched n times, and retry otherwise.
+// It is meant to be combined with other policies using And.
+//
+// The returned policy counts matches for its lifetime, so it should be
+// created per call of the retry mechanism, e.g. using WithRetryPolicyFactory
+// for a Retrier or any retry policy shared between calls.
+func LimitFor(matcher RetryPolicy, n int) RetryPolicy {
+	var matches int64
+	return func(err error) bool {
+		if !matcher(err) {
+			return true
+		}
+		return atomic.AddInt64(&matches, 1) <= int64(n)
+	}
+}
+
+// WithRetryPolicyFactory configures retry to also consult a retry policy
+// created per call by the given factory, performing retry only when both
+// the retry policy and the created policy allow it.
+// It is meant for stateful policies, e.g. LimitFor.
+func WithRetryPolicyFactory(newRetryPolicy func() RetryPolicy) RetryOption {
+	return func(ro *retryOptions) {
+		ro.attemptPolicies = append(ro.attemptPolicies, AttemptPolicyOf(newRetryPolicy()))
+	}
+}
+
+// RetryState describes the state of the retry mechanism provided to an AttemptPolicy.
+type RetryState struct {
+	// Attempt is the attempt that returned the error.
+	Attempt Attempt
+	// Elapsed is the time elapsed since the first attempt started.
+	Elapsed time.Duration
+	// Errors are the errors returned by all attempts, including the last one.
+	Errors []error
+}
+
+// AttemptPolicy function implements the policy for performing a retry,
+// aware of the attempt number, the elapsed time and the history of errors.
+type AttemptPolicy func(err error, state RetryState) bool
+
+// AttemptPolicyOf adapts a retry policy to an attempt policy.
+func AttemptPolicyOf(policy RetryPolicy) AttemptPolicy {
+	return func(err error, _ RetryState) bool {
+		return policy(err)
+	}
+}
+
+// MaxAttemptsFor creates an attempt policy performing at most n retries
+// for the errors the matcher matches, and retry otherwise.
+func MaxAttemptsFor(matcher RetryPolicy, n int) AttemptPolicy {
+	return func(err error, state RetryState) bool {
+		if !matcher(err) {
+			return true
+		}
+		matches := 0
+		for _, e := range state.Errors {
+			if matcher(e) {
+				matches++
+			}
+		}
+		return matches <= n
+	}
+}
+
+// MaxElapsedFor creates an attempt policy performing no retry for errors
+// the matcher matches after the given time has elapsed, and retry otherwise.
+func MaxElapsedFor(matcher RetryPolicy, d time.Duration) AttemptPolicy {
+	return func(err error, state RetryState) bool {
+		return !matcher(err) || state.Elapsed < d
+	}
+}
@@ -0,0 +1,187 @@
+package recovererr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyCombinators(t *testing.T) {
+	t.Parallel()
+
+	var (
+		errQuotaExceeded = errors.New("quota exceeded")
+		recoverable      = Recoverable(errors.New("connection error"))
+		quota            = Recoverable(fmt.Errorf("charge failed, %w", errQuotaExceeded))
+	)
+
+	t.Run("and", func(t *testing.T) {
+		policy := And(RetryRecoverablePolicy, ExceptErrorIs(errQuotaExceeded))
+
+		assert.True(t, policy(recoverable))
+		assert.False(t, policy(quota))
+		assert.False(t, policy(errors.New("any error")))
+		assert.True(t, And()(nil))
+	})
+
+	t.Run("or", func(t *testing.T) {
+		policy := Or(RetryRecoverablePolicy, IfErrorIs(io.EOF))
+
+		assert.True(t, policy(recoverable))
+		assert.True(t, policy(fmt.Errorf("read failed, %w", io.EOF)))
+		assert.False(t, policy(errors.New("any error")))
+		assert.False(t, Or()(recoverable))
+	})
+
+	t.Run("not", func(t *testing.T) {
+		assert.False(t, Not(RetryForever)(nil))
+		assert.True(t, Not(RetryRecoverablePolicy)(Unrecoverable(errors.New("failure"))))
+	})
+
+	t.Run("if error is", func(t *testing.T) {
+		policy := IfErrorIs(io.EOF, io.ErrUnexpectedEOF)
+
+		assert.True(t, policy(io.ErrUnexpectedEOF))
+		assert.False(t, policy(io.ErrClosedPipe))
+		assert.False(t, policy(nil))
+	})
+
+	t.Run("if error as", func(t *testing.T) {
+		policy := IfErrorAs[*customError]()
+
+		assert.True(t, policy(fmt.Errorf("wrapped, %w", &customError{message: "failure"})))
+		assert.False(t, policy(errors.New("any error")))
+		assert.False(t, policy(nil))
+	})
+
+	t.Run("except error is", func(t *testing.T) {
+		policy := ExceptErrorIs(errQuotaExceeded)
+
+		assert.False(t, policy(quota))
+		assert.True(t, policy(recoverable))
+	})
+
+	t.Run("limit for", func(t *testing.T) {
+		policy := And(RetryNonUnrecoverablePolicy, LimitFor(IfErrorIs(io.ErrUnexpectedEOF), 2))
+
+		assert.True(t, policy(io.ErrUnexpectedEOF))
+		assert.True(t, policy(errors.New("any error")))
+		assert.True(t, policy(io.ErrUnexpectedEOF))
+		assert.False(t, policy(io.ErrUnexpectedEOF))
+		assert.True(t, policy(errors.New("any error")))
+	})
+
+	t.Run("limit for retry", func(t *testing.T) {
+		action := &mockAction{errors: []error{io.ErrUnexpectedEOF}}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+		policy := And(RetryNonUnrecoverablePolicy, LimitFor(IfErrorIs(io.ErrUnexpectedEOF), 2))
+
+		err := retry(context.Background(), action.Call, &mockClock, NewConstantBackoff(WithInterval(time.Millisecond), WithMaxAttempts(10)), policy)
+
+		assert.Equal(t, io.ErrUnexpectedEOF, err)
+		assert.Equal(t, 3, action.callCounter)
+	})
+
+	t.Run("limit for per call", func(t *testing.T) {
+		r := NewRetrier(
+			WithBackoffStrategy(func() BackoffStrategy {
+				return NewConstantBackoff(WithInterval(time.Millisecond), WithMaxAttempts(10))
+			}),
+			WithRetryPolicy(RetryNonUnrecoverablePolicy),
+			WithRetrierClock(&mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}),
+			WithRetryOptions(WithRetryPolicyFactory(func() RetryPolicy {
+				return And(ExceptErrorIs(errQuotaExceeded), LimitFor(IfErrorIs(io.ErrUnexpectedEOF), 2))
+			})),
+		)
+
+		for i := 0; i < 3; i++ {
+			action := &mockAction{errors: []error{io.ErrUnexpectedEOF}}
+
+			err := r.Retry(context.Background(), action.Call)
+
+			assert.Equal(t, io.ErrUnexpectedEOF, err)
+			assert.Equal(t, 3, action.callCounter)
+		}
+	})
+}
+
+func TestAttemptPolicy(t *testing.T) {
+	t.Parallel()
+
+	var (
+		errTimeout = errors.New("timeout")
+		errReset   = errors.New("connection reset")
+	)
+
+	t.Run("max attempts per error kind", func(t *testing.T) {
+		action := &mockAction{errors: []error{errReset, errTimeout, errReset, errTimeout, errReset, errTimeout, nil}}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		err := retry(context.Background(), action.Call, &mockClock, NewConstantBackoff(WithInterval(time.Millisecond), WithMaxAttempts(10)), RetryNonUnrecoverablePolicy,
+			WithAttemptPolicy(MaxAttemptsFor(IfErrorIs(errTimeout), 2)),
+			WithAttemptPolicy(MaxAttemptsFor(IfErrorIs(errReset), 10)),
+		)
+
+		assert.Equal(t, errTimeout, err)
+		assert.Equal(t, 6, action.callCounter)
+	})
+
+	t.Run("max attempts per call", func(t *testing.T) {
+		r := NewRetrier(
+			WithBackoffStrategy(func() BackoffStrategy {
+				return NewConstantBackoff(WithInterval(time.Millisecond), WithMaxAttempts(10))
+			}),
+			WithRetryPolicy(RetryNonUnrecoverablePolicy),
+			WithRetrierClock(&mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}),
+			WithRetryOptions(WithAttemptPolicy(MaxAttemptsFor(IfErrorIs(io.ErrUnexpectedEOF), 2))),
+		)
+
+		for i := 0; i < 3; i++ {
+			action := &mockAction{errors: []error{io.ErrUnexpectedEOF}}
+
+			err := r.Retry(context.Background(), action.Call)
+
+			assert.Equal(t, io.ErrUnexpectedEOF, err)
+			assert.Equal(t, 3, action.callCounter)
+		}
+	})
+
+	t.Run("max elapsed per error kind", func(t *testing.T) {
+		policy := MaxElapsedFor(IfErrorIs(errTimeout), 2*time.Minute)
+
+		assert.True(t, policy(errTimeout, RetryState{Elapsed: time.Minute}))
+		assert.False(t, policy(errTimeout, RetryState{Elapsed: 2 * time.Minute}))
+		assert.True(t, policy(errReset, RetryState{Elapsed: time.Hour}))
+	})
+
+	t.Run("retry state", func(t *testing.T) {
+		var states []RetryState
+		action := &mockAction{errors: []error{errReset, errTimeout, nil}}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		err := retry(context.Background(), action.Call, &mockClock, NewConstantBackoff(WithInterval(time.Millisecond)), RetryNonUnrecoverablePolicy,
+			WithAttemptPolicy(func(err error, state RetryState) bool {
+				states = append(states, state)
+				return true
+			}),
+		)
+
+		assert.Nil(t, err)
+		assert.Len(t, states, 2)
+		assert.Equal(t, 2, states[1].Attempt.Number)
+		assert.Equal(t, []error{errReset, errTimeout}, states[1].Errors)
+		assert.True(t, states[1].Elapsed >= states[0].Elapsed)
+	})
+
+	t.Run("adapter of retry policy", func(t *testing.T) {
+		policy := AttemptPolicyOf(RetryRecoverablePolicy)
+
+		assert.True(t, policy(Recoverable(errReset), RetryState{}))
+		assert.False(t, policy(errReset, RetryState{}))
+	})
+}
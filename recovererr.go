@@ -4,35 +4,163 @@ package recovererr
 
 import (
 	"errors"
+	"time"
 )
 
 // Recoverable wraps an error as recoverable.
-func Recoverable(err error) error {
+func Recoverable(err error, opts ...ErrorOption) error {
 	if err == nil {
 		panic("recoverror: error cannot be nil")
 	}
-	return &recoveryError{err: err, recover: true}
+	return newRecoveryError(&recoveryError{err: err, recover: true}, opts)
+}
+
+// RecoverableAfter wraps an error as recoverable, hinting that retry
+// should not be performed before the given delay.
+func RecoverableAfter(err error, d time.Duration, opts ...ErrorOption) error {
+	if err == nil {
+		panic("recoverror: error cannot be nil")
+	}
+	return newRecoveryError(&recoveryError{err: err, recover: true, after: d}, opts)
+}
+
+// RecoverableAt wraps an error as recoverable, hinting that retry
+// should not be performed before the given time.
+func RecoverableAt(err error, t time.Time, opts ...ErrorOption) error {
+	if err == nil {
+		panic("recoverror: error cannot be nil")
+	}
+	return newRecoveryError(&recoveryError{err: err, recover: true, at: t}, opts)
 }
 
 // Unrecoverable wraps an error as unrecoverable.
-func Unrecoverable(err error) error {
+func Unrecoverable(err error, opts ...ErrorOption) error {
 	if err == nil {
 		panic("recoverror: error cannot be nil")
 	}
-	return &recoveryError{err: err}
+	return newRecoveryError(&recoveryError{err: err}, opts)
 }
 
+// Resolution defines how the recovery context of a multi-error is resolved
+// from the recovery context of the errors it wraps.
+type Resolution int
+
+const (
+	// ResolveAnyUnrecoverable resolves as unrecoverable when any wrapped error is
+	// unrecoverable. Wrapped errors with no recovery context are ignored.
+	ResolveAnyUnrecoverable Resolution = iota
+	// ResolveAllRecoverable resolves as recoverable only when every wrapped error
+	// is recoverable. Wrapped errors with no recovery context are not recoverable.
+	ResolveAllRecoverable
+	// ResolveFirstFound resolves to the first recovery context found, traversing
+	// the wrapped errors in order.
+	ResolveFirstFound
+)
+
 // DoRecover can be used by user to validate if error should be recovered.
 // When no recovery context is found in the given error, it returns
 // false in both values.
 // When recovery context is found in the given error, it returns
 // true in the first value and the recovery context of the error.
+//
+// Errors wrapping multiple errors, by implementing `Unwrap() []error`,
+// are resolved using ResolveAnyUnrecoverable.
 func DoRecover(err error) (bool, bool) {
-	for err != nil {
-		if x, ok := err.(interface{ Recover() bool }); ok {
+	return DoRecoverWith(err, ResolveAnyUnrecoverable)
+}
+
+// DoRecoverWith works like DoRecover, using the provided resolution
+// for errors wrapping multiple errors.
+//
+// When no recovery context is found in the error chain, the classifiers
+// registered by RegisterClassifier are consulted.
+func DoRecoverWith(err error, resolution Resolution) (bool, bool) {
+	return doRecover(err, resolution, registeredClassify)
+}
+
+func doRecover(err error, resolution Resolution, classify ClassifierFunc) (bool, bool) {
+	for e := err; e != nil; {
+		if x, ok := e.(interface{ recoveryCause() error }); ok {
+			e = x.recoveryCause()
+			continue
+		}
+		if x, ok := e.(interface{ Recover() bool }); ok {
 			return true, x.Recover()
 		}
+		if x, ok := e.(interface{ RecoveryClass() Class }); ok {
+			return true, x.RecoveryClass().Recover()
+		}
+		if x, ok := e.(interface{ Unwrap() []error }); ok {
+			if found, recover := resolve(x.Unwrap(), resolution, classify); found {
+				return true, recover
+			}
+			break
+		}
+		e = errors.Unwrap(e)
+	}
+	if err == nil {
+		return false, false
+	}
+	return classify(err)
+}
+
+// resolve combines the recovery context of multiple errors.
+func resolve(errs []error, resolution Resolution, classify ClassifierFunc) (bool, bool) {
+	var found, recover = false, true
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		f, r := doRecover(err, resolution, classify)
+		switch resolution {
+		case ResolveFirstFound:
+			if f {
+				return true, r
+			}
+		case ResolveAllRecoverable:
+			found = found || f
+			recover = recover && f && r
+		default:
+			if f {
+				found = true
+				recover = recover && r
+			}
+		}
+	}
+	return found, found && recover
+}
+
+// RetryAfter provides the retry delay hint of the given error.
+// When no hint is found in the given error, it returns false.
+//
+// Errors wrapping multiple errors resolve to the largest hint found.
+func RetryAfter(err error) (time.Duration, bool) {
+	for err != nil {
+		if x, ok := err.(interface{ recoveryCause() error }); ok {
+			err = x.recoveryCause()
+			continue
+		}
+		if x, ok := err.(interface{ RetryAfter() (time.Duration, bool) }); ok {
+			if d, ok := x.RetryAfter(); ok {
+				if d < 0 {
+					d = 0
+				}
+				return d, true
+			}
+		}
+		if x, ok := err.(interface{ Unwrap() []error }); ok {
+			var max, found = time.Duration(0), false
+			for _, err := range x.Unwrap() {
+				if d, ok := RetryAfter(err); ok {
+					found = true
+					if d > max {
+						max = d
+					}
+				}
+			}
+			return max, found
+		}
 		err = errors.Unwrap(err)
 	}
-	return false, false
+	return 0, false
 }
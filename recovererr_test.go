@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -56,6 +57,104 @@ func TestDoRecover(t *testing.T) {
 	})
 }
 
+func TestDoRecoverWith(t *testing.T) {
+	var (
+		recoverable   = Recoverable(errors.New("connection error"))
+		unrecoverable = Unrecoverable(errors.New("parse error"))
+		plain         = errors.New("any error")
+	)
+	tests := []struct {
+		name       string
+		err        error
+		resolution Resolution
+		found      bool
+		recover    bool
+	}{
+		{"any unrecoverable all recoverable", &joinError{[]error{recoverable, recoverable}}, ResolveAnyUnrecoverable, true, true},
+		{"any unrecoverable mixed", &joinError{[]error{recoverable, unrecoverable}}, ResolveAnyUnrecoverable, true, false},
+		{"any unrecoverable ignores no context", &joinError{[]error{plain, recoverable}}, ResolveAnyUnrecoverable, true, true},
+		{"any unrecoverable no context", &joinError{[]error{plain, plain}}, ResolveAnyUnrecoverable, false, false},
+		{"all recoverable all recoverable", &joinError{[]error{recoverable, recoverable}}, ResolveAllRecoverable, true, true},
+		{"all recoverable with no context", &joinError{[]error{plain, recoverable}}, ResolveAllRecoverable, true, false},
+		{"first found recoverable", &joinError{[]error{plain, recoverable, unrecoverable}}, ResolveFirstFound, true, true},
+		{"first found unrecoverable", &joinError{[]error{unrecoverable, recoverable}}, ResolveFirstFound, true, false},
+		{"nested join wrapped", fmt.Errorf("batch failed, %w", &joinError{[]error{recoverable, &joinError{[]error{unrecoverable}}}}), ResolveAnyUnrecoverable, true, false},
+		{"recovery context wrapping join", Recoverable(&joinError{[]error{unrecoverable}}), ResolveAnyUnrecoverable, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, recover := DoRecoverWith(tt.err, tt.resolution)
+			assert.Equal(t, tt.found, found, tt.err)
+			assert.Equal(t, tt.recover, recover, tt.err)
+		})
+	}
+
+	t.Run("do recover resolves any unrecoverable", func(t *testing.T) {
+		found, recover := DoRecover(&joinError{[]error{recoverable, unrecoverable}})
+		assert.True(t, found)
+		assert.False(t, recover)
+	})
+
+	t.Run("retry policies with resolution", func(t *testing.T) {
+		mixed := &joinError{[]error{recoverable, unrecoverable}}
+		assert.False(t, RetryRecoverablePolicy(mixed))
+		assert.True(t, RetryRecoverablePolicyWith(ResolveFirstFound)(mixed))
+		assert.False(t, RetryRecoverablePolicyWith(ResolveFirstFound)(nil))
+
+		partial := &joinError{[]error{plain, recoverable}}
+		assert.True(t, RetryNonUnrecoverablePolicy(partial))
+		assert.False(t, RetryNonUnrecoverablePolicyWith(ResolveAllRecoverable)(partial))
+		assert.False(t, RetryNonUnrecoverablePolicyWith(ResolveAllRecoverable)(nil))
+	})
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Run("recoverable after", func(t *testing.T) {
+		d, ok := RetryAfter(RecoverableAfter(errors.New("throttled"), time.Second))
+		assert.True(t, ok)
+		assert.Equal(t, time.Second, d)
+	})
+
+	t.Run("recoverable at", func(t *testing.T) {
+		d, ok := RetryAfter(RecoverableAt(errors.New("throttled"), time.Now().Add(time.Hour)))
+		assert.True(t, ok)
+		assert.True(t, d > 59*time.Minute, d)
+	})
+
+	t.Run("recoverable at past time", func(t *testing.T) {
+		d, ok := RetryAfter(RecoverableAt(errors.New("throttled"), time.Now().Add(-time.Hour)))
+		assert.True(t, ok)
+		assert.Equal(t, time.Duration(0), d)
+	})
+
+	t.Run("recoverable after is recoverable", func(t *testing.T) {
+		found, recover := DoRecover(RecoverableAfter(errors.New("throttled"), time.Second))
+		assert.True(t, found)
+		assert.True(t, recover)
+	})
+
+	t.Run("no hint", func(t *testing.T) {
+		_, ok := RetryAfter(Recoverable(errors.New("failure")))
+		assert.False(t, ok)
+	})
+
+	t.Run("hint wrapped in recovery context", func(t *testing.T) {
+		d, ok := RetryAfter(Unrecoverable(RecoverableAfter(errors.New("throttled"), time.Second)))
+		assert.True(t, ok)
+		assert.Equal(t, time.Second, d)
+	})
+
+	t.Run("largest hint of multi-error", func(t *testing.T) {
+		d, ok := RetryAfter(&joinError{[]error{
+			RecoverableAfter(errors.New("throttled"), time.Second),
+			RecoverableAfter(errors.New("throttled"), time.Minute),
+			errors.New("any error"),
+		}})
+		assert.True(t, ok)
+		assert.Equal(t, time.Minute, d)
+	})
+}
+
 func TestRecoverable(t *testing.T) {
 	t.Run("nil error", func(t *testing.T) {
 		defer func() {
@@ -96,6 +195,14 @@ type anyError struct{}
 
 func (ae anyError) Error() string { return "" }
 
+type joinError struct {
+	errs []error
+}
+
+func (je *joinError) Error() string { return fmt.Sprint(je.errs) }
+
+func (je *joinError) Unwrap() []error { return je.errs }
+
 type otherRecoverError struct {
 	recover bool
 }
@@ -0,0 +1,148 @@
+package recovererr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Class grades the recovery context of an error.
+type Class int
+
+const (
+	// ClassTransient is a temporary failure, expected to recover on retry.
+	ClassTransient Class = iota + 1
+	// ClassThrottled is a failure caused by rate limiting, expected to recover after a delay.
+	ClassThrottled
+	// ClassDegraded is a failure of a dependency running with reduced capacity.
+	ClassDegraded
+	// ClassPermanent is a failure that will not recover on retry.
+	ClassPermanent
+	// ClassFatal is a failure that will not recover and should stop any further processing.
+	ClassFatal
+)
+
+// Recover provides if errors of the class should be recovered.
+func (c Class) Recover() bool {
+	return c == ClassTransient || c == ClassThrottled || c == ClassDegraded
+}
+
+// String returns the class in string format.
+func (c Class) String() string {
+	switch c {
+	case ClassTransient:
+		return "transient"
+	case ClassThrottled:
+		return "throttled"
+	case ClassDegraded:
+		return "degraded"
+	case ClassPermanent:
+		return "permanent"
+	case ClassFatal:
+		return "fatal"
+	}
+	return fmt.Sprintf("class(%d)", int(c))
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (c Class) MarshalText() ([]byte, error) {
+	if c < ClassTransient || c > ClassFatal {
+		return nil, fmt.Errorf("recovererr: invalid class %d", int(c))
+	}
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (c *Class) UnmarshalText(text []byte) error {
+	for class := ClassTransient; class <= ClassFatal; class++ {
+		if class.String() == string(text) {
+			*c = class
+			return nil
+		}
+	}
+	return fmt.Errorf("recovererr: unknown class %q", text)
+}
+
+// Classified wraps an error with the given recovery class.
+func Classified(err error, class Class, opts ...ErrorOption) error {
+	if err == nil {
+		panic("recoverror: error cannot be nil")
+	}
+	return newRecoveryError(&recoveryError{err: err, recover: class.Recover(), class: class}, opts)
+}
+
+// DoClassify can be used by user to retrieve the recovery class of an error.
+// When no recovery context is found in the given error, it returns
+// false in the first value.
+// Errors implementing only `Recover() bool` are classified as
+// ClassTransient when recoverable and ClassPermanent otherwise.
+//
+// Errors wrapping multiple errors resolve to the most severe class found.
+// Errors with no recovery context are classified by the registered classifiers.
+func DoClassify(err error) (bool, Class) {
+	if found, class := doClassify(err); found {
+		return true, class
+	}
+	if err == nil {
+		return false, 0
+	}
+	if found, recover := registeredClassify(err); found {
+		return true, classOf(recover)
+	}
+	return false, 0
+}
+
+func doClassify(err error) (bool, Class) {
+	for err != nil {
+		if x, ok := err.(interface{ recoveryCause() error }); ok {
+			err = x.recoveryCause()
+			continue
+		}
+		if x, ok := err.(interface{ RecoveryClass() Class }); ok {
+			return true, x.RecoveryClass()
+		}
+		if x, ok := err.(interface{ Recover() bool }); ok {
+			return true, classOf(x.Recover())
+		}
+		if x, ok := err.(interface{ Unwrap() []error }); ok {
+			var found, class = false, Class(0)
+			for _, err := range x.Unwrap() {
+				if f, c := DoClassify(err); f {
+					found = true
+					if c > class {
+						class = c
+					}
+				}
+			}
+			return found, class
+		}
+		err = errors.Unwrap(err)
+	}
+	return false, 0
+}
+
+// RetryClassPolicy creates a retry policy performing retry for errors
+// of the given recovery classes.
+func RetryClassPolicy(classes ...Class) RetryPolicy {
+	return func(err error) bool {
+		if err == nil {
+			return false
+		}
+		found, class := DoClassify(err)
+		if !found {
+			return false
+		}
+		for _, c := range classes {
+			if c == class {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func classOf(recover bool) Class {
+	if recover {
+		return ClassTransient
+	}
+	return ClassPermanent
+}
@@ -0,0 +1,95 @@
+package recovererr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoClassify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		err   error
+		found bool
+		class Class
+	}{
+		{"classified throttled", Classified(errors.New("too many requests"), ClassThrottled), true, ClassThrottled},
+		{"classified fatal wrapped", fmt.Errorf("failed to start, %w", Classified(errors.New("disk full"), ClassFatal)), true, ClassFatal},
+		{"recoverable", Recoverable(errors.New("connection error")), true, ClassTransient},
+		{"unrecoverable", Unrecoverable(errors.New("parse error")), true, ClassPermanent},
+		{"other recover error implementation", &otherRecoverError{recover: true}, true, ClassTransient},
+		{"other class error implementation", &otherClassError{class: ClassDegraded}, true, ClassDegraded},
+		{"no recovery context", errors.New("any error"), false, 0},
+		{"most severe of multi-error", &joinError{[]error{
+			Classified(errors.New("too many requests"), ClassThrottled),
+			Classified(errors.New("not found"), ClassPermanent),
+			errors.New("any error"),
+		}}, true, ClassPermanent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, class := DoClassify(tt.err)
+			assert.Equal(t, tt.found, found, tt.err)
+			assert.Equal(t, tt.class, class, tt.err)
+		})
+	}
+}
+
+func TestClassified(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil error", func(t *testing.T) {
+		defer func() {
+			assert.NotNil(t, recover(), "expected panic")
+		}()
+
+		Classified(nil, ClassTransient)
+	})
+
+	t.Run("recover of classes", func(t *testing.T) {
+		for class, recover := range map[Class]bool{
+			ClassTransient: true,
+			ClassThrottled: true,
+			ClassDegraded:  true,
+			ClassPermanent: false,
+			ClassFatal:     false,
+		} {
+			found, r := DoRecover(Classified(errors.New("failure"), class))
+			assert.True(t, found, class)
+			assert.Equal(t, recover, r, class)
+		}
+	})
+
+	t.Run("class only implementation", func(t *testing.T) {
+		found, recover := DoRecover(&otherClassError{class: ClassThrottled})
+		assert.True(t, found)
+		assert.True(t, recover)
+	})
+}
+
+func TestRetryClassPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryClassPolicy(ClassTransient, ClassThrottled)
+
+	assert.True(t, policy(Classified(errors.New("too many requests"), ClassThrottled)))
+	assert.True(t, policy(Recoverable(errors.New("connection error"))))
+	assert.False(t, policy(Classified(errors.New("overloaded"), ClassDegraded)))
+	assert.False(t, policy(errors.New("any error")))
+	assert.False(t, policy(nil))
+}
+
+type otherClassError struct {
+	class Class
+}
+
+func (oce otherClassError) Error() string {
+	return fmt.Sprintf("class: %s", oce.class)
+}
+func (oce otherClassError) RecoveryClass() Class {
+	return oce.class
+}
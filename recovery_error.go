@@ -1,10 +1,65 @@
 package recovererr
 
-import "strings"
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+)
 
 type recoveryError struct {
 	recover bool
+	class   Class
 	err     error
+
+	after time.Duration
+	at    time.Time
+
+	reason     string
+	dependency string
+	attrs      []Attr
+
+	captureStack bool
+	stack        []uintptr
+}
+
+// ErrorOption configures the recovery error created by wrapping an error.
+type ErrorOption func(*recoveryError)
+
+// WithStack captures the call stack when wrapping the error.
+func WithStack() ErrorOption {
+	return func(re *recoveryError) {
+		re.captureStack = true
+	}
+}
+
+var captureStacks int32
+
+// CaptureStacks enables capturing the call stack when wrapping any error.
+// Capturing is disabled by default, to keep wrapping cheap.
+func CaptureStacks(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&captureStacks, v)
+}
+
+// newRecoveryError applies the options to the recovery error, capturing
+// the call stack of the caller of the exported constructor if enabled.
+func newRecoveryError(re *recoveryError, opts []ErrorOption) error {
+	for _, opt := range opts {
+		opt(re)
+	}
+	if re.captureStack || atomic.LoadInt32(&captureStacks) == 1 {
+		var pcs [32]uintptr
+		n := runtime.Callers(3, pcs[:])
+		re.stack = pcs[:n]
+	}
+	return re
 }
 
 // Error returns the error in string format.
@@ -19,11 +74,82 @@ func (re recoveryError) Error() string {
 	return sb.String()
 }
 
+// Format implements the fmt.Formatter interface.
+//
+// The `%+v` verb prints the error along with the captured call stack,
+// followed by the next formattable error of the chain.
+func (re recoveryError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			io.WriteString(s, re.Error())
+			re.formatStack(s)
+			for err := re.err; err != nil; err = errors.Unwrap(err) {
+				if _, ok := err.(fmt.Formatter); ok {
+					fmt.Fprintf(s, "\ncaused by: %+v", err)
+					break
+				}
+			}
+			return
+		}
+		io.WriteString(s, re.Error())
+	case 's':
+		io.WriteString(s, re.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", re.Error())
+	}
+}
+
+func (re recoveryError) formatStack(w io.Writer) {
+	if len(re.stack) == 0 {
+		return
+	}
+	frames := runtime.CallersFrames(re.stack)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(w, "\n\t%s\n\t\t%s:%d", frame.Function, frame.File, frame.Line)
+		if !more {
+			return
+		}
+	}
+}
+
 // Recover provides if should recover from error.
 func (re recoveryError) Recover() bool {
 	return re.recover
 }
 
+// RecoveryClass provides the recovery class of the error.
+func (re recoveryError) RecoveryClass() Class {
+	if re.class != 0 {
+		return re.class
+	}
+	return classOf(re.recover)
+}
+
+// RetryAfter provides the delay to wait before retrying, if hinted.
+func (re recoveryError) RetryAfter() (time.Duration, bool) {
+	if !re.at.IsZero() {
+		return time.Until(re.at), true
+	}
+	return re.after, re.after > 0
+}
+
+// Reason provides the reason code of the error.
+func (re recoveryError) Reason() string {
+	return re.reason
+}
+
+// Dependency provides the name of the failed dependency.
+func (re recoveryError) Dependency() string {
+	return re.dependency
+}
+
+// Attrs provides the attributes of the error.
+func (re recoveryError) Attrs() []Attr {
+	return re.attrs
+}
+
 // Unwrap provides the wrapped error.
 func (re recoveryError) Unwrap() error {
 	return re.err
@@ -2,6 +2,8 @@ package recovererr
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -38,3 +40,43 @@ func TestrecoveryError_Error(t *testing.T) {
 		assert.Equal(t, "unrecover: test", re.Error())
 	})
 }
+
+func TestRecoveryError_Format(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no stack", func(t *testing.T) {
+		err := Recoverable(errors.New("test"))
+
+		assert.Equal(t, "recover: test", fmt.Sprintf("%v", err))
+		assert.Equal(t, "recover: test", fmt.Sprintf("%s", err))
+		assert.Equal(t, `"recover: test"`, fmt.Sprintf("%q", err))
+		assert.Equal(t, "recover: test", fmt.Sprintf("%+v", err))
+	})
+
+	t.Run("stack per call", func(t *testing.T) {
+		err := Unrecoverable(errors.New("test"), WithStack())
+
+		out := fmt.Sprintf("%+v", err)
+		assert.True(t, strings.HasPrefix(out, "unrecover: test\n"), out)
+		assert.Contains(t, out, "TestRecoveryError_Format")
+		assert.Contains(t, out, "recovery_error_test.go")
+		assert.NotContains(t, out, "newRecoveryError")
+	})
+
+	t.Run("chain of stacks", func(t *testing.T) {
+		err := Unrecoverable(fmt.Errorf("wrapped, %w", Recoverable(errors.New("test"), WithStack())), WithStack())
+
+		out := fmt.Sprintf("%+v", err)
+		assert.True(t, strings.HasPrefix(out, "unrecover: wrapped, recover: test\n"), out)
+		assert.Contains(t, out, "\ncaused by: recover: test\n")
+	})
+}
+
+func TestCaptureStacks(t *testing.T) {
+	CaptureStacks(true)
+	defer CaptureStacks(false)
+
+	err := Recoverable(errors.New("test"))
+
+	assert.Contains(t, fmt.Sprintf("%+v", err), "TestCaptureStacks")
+}
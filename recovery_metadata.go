@@ -0,0 +1,86 @@
+package recovererr
+
+import "errors"
+
+// Attr is a key/value attribute attached to a recovery error.
+type Attr struct {
+	Key   string
+	Value interface{}
+}
+
+// WithReason attaches a machine-readable reason code to the recovery error.
+func WithReason(reason string) ErrorOption {
+	return func(re *recoveryError) {
+		re.reason = reason
+	}
+}
+
+// WithDependency attaches the name of the failed dependency to the recovery error.
+func WithDependency(name string) ErrorOption {
+	return func(re *recoveryError) {
+		re.dependency = name
+	}
+}
+
+// WithAttr attaches a key/value attribute to the recovery error.
+func WithAttr(key string, value interface{}) ErrorOption {
+	return func(re *recoveryError) {
+		re.attrs = append(re.attrs, Attr{Key: key, Value: value})
+	}
+}
+
+// Reason provides the first reason code found in the error chain.
+func Reason(err error) (string, bool) {
+	var reason string
+	walk(err, func(err error) bool {
+		if x, ok := err.(interface{ Reason() string }); ok {
+			reason = x.Reason()
+		}
+		return reason != ""
+	})
+	return reason, reason != ""
+}
+
+// Dependency provides the first dependency name found in the error chain.
+func Dependency(err error) (string, bool) {
+	var dependency string
+	walk(err, func(err error) bool {
+		if x, ok := err.(interface{ Dependency() string }); ok {
+			dependency = x.Dependency()
+		}
+		return dependency != ""
+	})
+	return dependency, dependency != ""
+}
+
+// Attrs provides the attributes found in the error chain, starting from
+// the outermost error. An attribute key may appear more than once.
+func Attrs(err error) []Attr {
+	var attrs []Attr
+	walk(err, func(err error) bool {
+		if x, ok := err.(interface{ Attrs() []Attr }); ok {
+			attrs = append(attrs, x.Attrs()...)
+		}
+		return false
+	})
+	return attrs
+}
+
+// walk traverses the error tree depth-first, until visit returns true.
+func walk(err error, visit func(error) bool) bool {
+	for err != nil {
+		if visit(err) {
+			return true
+		}
+		if x, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, err := range x.Unwrap() {
+				if walk(err, visit) {
+					return true
+				}
+			}
+			return false
+		}
+		err = errors.Unwrap(err)
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package recovererr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecoveryMetadata(t *testing.T) {
+	t.Parallel()
+
+	t.Run("metadata of wrapped error", func(t *testing.T) {
+		err := fmt.Errorf("failed to store order, %w", Recoverable(errors.New("deadlock"),
+			WithReason("db.deadlock"),
+			WithDependency("postgres"),
+			WithAttr("table", "orders"),
+			WithAttr("retries", 3),
+		))
+
+		reason, ok := Reason(err)
+		assert.True(t, ok)
+		assert.Equal(t, "db.deadlock", reason)
+
+		dependency, ok := Dependency(err)
+		assert.True(t, ok)
+		assert.Equal(t, "postgres", dependency)
+
+		assert.Equal(t, []Attr{{Key: "table", Value: "orders"}, {Key: "retries", Value: 3}}, Attrs(err))
+	})
+
+	t.Run("outermost reason of chain", func(t *testing.T) {
+		err := Unrecoverable(
+			Recoverable(errors.New("deadlock"), WithReason("db.deadlock"), WithAttr("table", "orders")),
+			WithReason("order.failed"),
+			WithAttr("order", 1),
+		)
+
+		reason, _ := Reason(err)
+		assert.Equal(t, "order.failed", reason)
+		assert.Equal(t, []Attr{{Key: "order", Value: 1}, {Key: "table", Value: "orders"}}, Attrs(err))
+	})
+
+	t.Run("inner reason of chain", func(t *testing.T) {
+		err := Unrecoverable(Recoverable(errors.New("deadlock"), WithReason("db.deadlock")))
+
+		reason, ok := Reason(err)
+		assert.True(t, ok)
+		assert.Equal(t, "db.deadlock", reason)
+
+		_, ok = Dependency(err)
+		assert.False(t, ok)
+	})
+
+	t.Run("metadata of multi-error", func(t *testing.T) {
+		err := &joinError{[]error{
+			errors.New("any error"),
+			Classified(errors.New("too many requests"), ClassThrottled, WithDependency("payments"), WithAttr("status", 429)),
+		}}
+
+		dependency, ok := Dependency(err)
+		assert.True(t, ok)
+		assert.Equal(t, "payments", dependency)
+		assert.Equal(t, []Attr{{Key: "status", Value: 429}}, Attrs(err))
+	})
+
+	t.Run("no metadata", func(t *testing.T) {
+		err := errors.New("any error")
+
+		_, ok := Reason(err)
+		assert.False(t, ok)
+		assert.Empty(t, Attrs(err))
+		_, ok = Reason(nil)
+		assert.False(t, ok)
+	})
+}
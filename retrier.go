@@ -0,0 +1,99 @@
+package recovererr
+
+import "context"
+
+// Retrier performs retries using the configured backoff strategy factory,
+// retry policy, clock and retry options.
+//
+// A fresh backoff strategy is created per call, so a Retrier is safe
+// for concurrent use.
+type Retrier struct {
+	newBackoffStrategy func() BackoffStrategy
+	retryPolicy        RetryPolicy
+	clock              Clock
+	opts               []RetryOption
+}
+
+// NewRetrier creates new retrier using provided options.
+//
+// By default, a retrier performs retries of recoverable errors using
+// constant backoff with default parameters.
+func NewRetrier(opts ...RetrierOption) *Retrier {
+	r := Retrier{}
+
+	for _, opt := range opts {
+		opt(&r)
+	}
+
+	if r.newBackoffStrategy == nil {
+		r.newBackoffStrategy = func() BackoffStrategy { return NewConstantBackoff() }
+	}
+	if r.retryPolicy == nil {
+		r.retryPolicy = RetryRecoverablePolicy
+	}
+	if r.clock == nil {
+		r.clock = &SystemClock{}
+	}
+
+	return &r
+}
+
+// RetrierOption configures retrier parameters.
+type RetrierOption func(*Retrier)
+
+// WithBackoffStrategy configures retrier with the factory of the backoff strategy used per call.
+func WithBackoffStrategy(newBackoffStrategy func() BackoffStrategy) RetrierOption {
+	return func(r *Retrier) {
+		r.newBackoffStrategy = newBackoffStrategy
+	}
+}
+
+// WithRetryPolicy configures retrier with specified retry policy.
+func WithRetryPolicy(retryPolicy RetryPolicy) RetrierOption {
+	return func(r *Retrier) {
+		r.retryPolicy = retryPolicy
+	}
+}
+
+// WithRetrierClock configures retrier with specified clock.
+func WithRetrierClock(clock Clock) RetrierOption {
+	return func(r *Retrier) {
+		r.clock = clock
+	}
+}
+
+// WithRetryOptions configures retrier with retry options applied to every call,
+// such as hooks and limits.
+func WithRetryOptions(opts ...RetryOption) RetrierOption {
+	return func(r *Retrier) {
+		r.opts = append(r.opts, opts...)
+	}
+}
+
+// Do works like the package Do function, using the retrier configuration.
+// The given options are applied after the options of the retrier.
+func (r *Retrier) Do(ctx context.Context, f func() error, opts ...RetryOption) error {
+	return r.DoAttempt(ctx, ignoreAttempt(f), opts...)
+}
+
+// DoAttempt works like the package DoAttempt function, using the retrier configuration.
+// The given options are applied after the options of the retrier.
+func (r *Retrier) DoAttempt(ctx context.Context, f func(context.Context, Attempt) error, opts ...RetryOption) error {
+	return doAttempt(ctx, f, r.clock, r.newBackoffStrategy, r.retryPolicy, r.options(opts)...)
+}
+
+// Retry works like the package Retry function, using a fresh backoff strategy
+// and the retrier configuration.
+// The given options are applied after the options of the retrier.
+func (r *Retrier) Retry(ctx context.Context, f func() error, opts ...RetryOption) error {
+	return retryAttempt(ctx, ignoreAttempt(f), r.clock, r.newBackoffStrategy(), r.retryPolicy, r.options(opts)...)
+}
+
+// options provides the retrier options followed by the given options,
+// without modifying the retrier.
+func (r *Retrier) options(opts []RetryOption) []RetryOption {
+	if len(opts) == 0 {
+		return r.opts
+	}
+	return append(r.opts[:len(r.opts):len(r.opts)], opts...)
+}
@@ -0,0 +1,102 @@
+package recovererr
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetrier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fresh backoff per call", func(t *testing.T) {
+		r := NewRetrier(
+			WithBackoffStrategy(func() BackoffStrategy {
+				return NewConstantBackoff(WithInterval(time.Millisecond), WithMaxAttempts(2))
+			}),
+			WithRetrierClock(&mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}),
+		)
+
+		for i := 0; i < 3; i++ {
+			action := &mockAction{errors: []error{Recoverable(errors.New("failure"))}}
+
+			_ = r.Retry(context.Background(), action.Call)
+
+			assert.Equal(t, 3, action.callCounter)
+		}
+		for i := 0; i < 3; i++ {
+			action := &mockAction{errors: []error{Recoverable(errors.New("failure"))}}
+
+			_ = r.Do(context.Background(), action.Call)
+
+			assert.Equal(t, 3, action.callCounter)
+		}
+	})
+
+	t.Run("retry policy", func(t *testing.T) {
+		r := NewRetrier(
+			WithRetryPolicy(RetryNonUnrecoverablePolicy),
+			WithBackoffStrategy(func() BackoffStrategy {
+				return NewConstantBackoff(WithInterval(time.Millisecond), WithMaxAttempts(1))
+			}),
+		)
+		action := &mockAction{errors: []error{errors.New("failure")}}
+
+		_ = r.Do(context.Background(), action.Call)
+
+		assert.Equal(t, 2, action.callCounter)
+	})
+
+	t.Run("retrier and call options", func(t *testing.T) {
+		var events []string
+		r := NewRetrier(
+			WithRetrierClock(&mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}),
+			WithRetryOptions(WithOnSuccess(func(int) { events = append(events, "retrier") })),
+		)
+
+		err := r.DoAttempt(context.Background(), func(ctx context.Context, a Attempt) error {
+			return nil
+		}, WithOnSuccess(func(int) { events = append(events, "call") }))
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"retrier", "call"}, events)
+		assert.Len(t, r.opts, 1)
+	})
+
+	t.Run("concurrent calls", func(t *testing.T) {
+		var successes int32
+		r := NewRetrier(
+			WithBackoffStrategy(func() BackoffStrategy {
+				return NewConstantBackoff(WithInterval(time.Microsecond), WithMaxAttempts(5))
+			}),
+			WithRetryOptions(WithOnSuccess(func(int) { atomic.AddInt32(&successes, 1) })),
+		)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				failures := 3
+				err := r.Retry(context.Background(), func() error {
+					if failures > 0 {
+						failures--
+						return Recoverable(errors.New("failure"))
+					}
+					return nil
+				}, WithRetryAfterMode(RetryAfterIgnore))
+
+				assert.Nil(t, err)
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(20), atomic.LoadInt32(&successes))
+	})
+}
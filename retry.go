@@ -2,77 +2,201 @@ package recovererr
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"time"
 )
 
 // Do will run a funtion and initiate retries if it fails.
 //
 // The call to `Retry` is postponed until an error is returned by the function.
-func Do(ctx context.Context, f func() error, newBackoffStrategy func() BackoffStrategy, retryPolicy RetryPolicy) error {
-	return do(ctx, f, &SystemClock{}, newBackoffStrategy, retryPolicy)
+func Do(ctx context.Context, f func() error, newBackoffStrategy func() BackoffStrategy, retryPolicy RetryPolicy, opts ...RetryOption) error {
+	return do(ctx, f, &SystemClock{}, newBackoffStrategy, retryPolicy, opts...)
 }
 
-func do(ctx context.Context, f func() error, clock Clock, newBackoffStrategy func() BackoffStrategy, retryPolicy RetryPolicy) error {
-	err := f()
-
-	// exit if should not retry
-	if !retryPolicy(err) {
-		return err
-	}
-
-	// initiate backoff strategy
-	backoffStrategy := newBackoffStrategy()
-
-	delay, doRetry := backoffStrategy.Next()
-	// exit if delay is over
-	if !doRetry {
-		return err
-	}
+func do(ctx context.Context, f func() error, clock Clock, newBackoffStrategy func() BackoffStrategy, retryPolicy RetryPolicy, opts ...RetryOption) error {
+	return doAttempt(ctx, ignoreAttempt(f), clock, newBackoffStrategy, retryPolicy, opts...)
+}
 
-	select {
-	case <-ctx.Done():
-		if err != nil {
-			return fmt.Errorf("%v, %w", ctx.Err(), err)
-		}
-		return nil
-	case <-clock.After(delay):
-	}
+// DoAttempt works like Do, for a function receiving the context and the attempt.
+func DoAttempt(ctx context.Context, f func(context.Context, Attempt) error, newBackoffStrategy func() BackoffStrategy, retryPolicy RetryPolicy, opts ...RetryOption) error {
+	return doAttempt(ctx, f, &SystemClock{}, newBackoffStrategy, retryPolicy, opts...)
+}
 
-	return retry(ctx, f, clock, backoffStrategy, retryPolicy)
+func doAttempt(ctx context.Context, f func(context.Context, Attempt) error, clock Clock, newBackoffStrategy func() BackoffStrategy, retryPolicy RetryPolicy, opts ...RetryOption) error {
+	ro := newRetryOptions(opts...)
+	return retryLoop(ctx, ro.wrap(f), clock, newBackoffStrategy, retryPolicy, ro)
 }
 
 // Retry will run the provided function.
 //
 // If the function fails, retryPolicy is used to extract the recovery context.
 // Retry will be performed on intervals provided by a time channel until the context is cancelled.
-func Retry(ctx context.Context, f func() error, backoffStrategy BackoffStrategy, retryPolicy RetryPolicy) error {
-	return retry(ctx, f, &SystemClock{}, backoffStrategy, retryPolicy)
+// A retry delay hint carried by the error, see RecoverableAfter, is honoured according to WithRetryAfterMode.
+func Retry(ctx context.Context, f func() error, backoffStrategy BackoffStrategy, retryPolicy RetryPolicy, opts ...RetryOption) error {
+	return retry(ctx, f, &SystemClock{}, backoffStrategy, retryPolicy, opts...)
+}
+
+func retry(ctx context.Context, f func() error, clock Clock, backoffStrategy BackoffStrategy, retryPolicy RetryPolicy, opts ...RetryOption) error {
+	return retryAttempt(ctx, ignoreAttempt(f), clock, backoffStrategy, retryPolicy, opts...)
+}
+
+// RetryAttempt works like Retry, for a function receiving the context and the attempt.
+func RetryAttempt(ctx context.Context, f func(context.Context, Attempt) error, backoffStrategy BackoffStrategy, retryPolicy RetryPolicy, opts ...RetryOption) error {
+	return retryAttempt(ctx, f, &SystemClock{}, backoffStrategy, retryPolicy, opts...)
+}
+
+func retryAttempt(ctx context.Context, f func(context.Context, Attempt) error, clock Clock, backoffStrategy BackoffStrategy, retryPolicy RetryPolicy, opts ...RetryOption) error {
+	ro := newRetryOptions(opts...)
+	newBackoffStrategy := func() BackoffStrategy { return backoffStrategy }
+	return retryLoop(ctx, ro.wrap(f), clock, newBackoffStrategy, retryPolicy, ro)
 }
 
-func retry(ctx context.Context, f func() error, clock Clock, backoffStrategy BackoffStrategy, retryPolicy RetryPolicy) error {
+// retryLoop runs the function until the retry policy or the backoff strategy stop it.
+// The backoff strategy is initiated on the first retry.
+func retryLoop(ctx context.Context, f func(context.Context, Attempt) error, clock Clock, newBackoffStrategy func() BackoffStrategy, retryPolicy RetryPolicy, ro *retryOptions) error {
+	ctx, endTask := ro.startTask(ctx)
+	defer endTask()
+
+	var (
+		backoffStrategy BackoffStrategy
+		attempt         = Attempt{Number: 1}
+		first           = time.Now()
+		history         []AttemptRecord
+		errs            []error
+		final           bool
+	)
+	// finish returns the error, along with the attempts history if configured
+	finish := func(err error, reason GiveUpReason) error {
+		if err == nil {
+			ro.hooks.success(attempt.Number)
+			return nil
+		}
+		if ro.retryError {
+			err = &RetryError{Err: err, Reason: reason, Attempts: history}
+		}
+		ro.hooks.giveUp(err, reason)
+		return err
+	}
 	for {
-		err := f()
+		attempt.Start = time.Now()
+		attempt.Elapsed = attempt.Start.Sub(first)
+		ro.hooks.attempt(attempt)
+
+		err := f(ctx, attempt)
+		if ro.retryError && err != nil {
+			history = append(history, AttemptRecord{Err: err, Start: attempt.Start, Duration: time.Since(attempt.Start)})
+		}
+		if len(ro.attemptPolicies) > 0 || ro.decisionPolicy != nil {
+			errs = append(errs, err)
+		}
+		state := RetryState{Attempt: attempt, Elapsed: time.Since(first), Errors: errs}
 		// exit if should not retry
-		if !retryPolicy(err) {
-			return err
+		if !retryPolicy(err) || !ro.allowRetry(err, state) {
+			return finish(err, giveUpReason(err))
+		}
+		decision := ro.decide(err, state)
+		if decision.kind == decisionStop {
+			return finish(err, GiveUpPolicyStopped)
 		}
 
-		delay, doRetry := backoffStrategy.Next()
-		// exit if delay is over
+		// initiate backoff strategy
+		if backoffStrategy == nil {
+			backoffStrategy = newBackoffStrategy()
+		} else if decision.kind == decisionReset || (err == nil && ro.resetOnSuccess) {
+			if rbs, ok := backoffStrategy.(ResettableBackoffStrategy); ok {
+				rbs.Reset()
+			} else {
+				backoffStrategy = newBackoffStrategy()
+			}
+		}
+
+		var (
+			delay   time.Duration
+			doRetry bool
+		)
+		switch decision.kind {
+		case decisionDelay:
+			delay = decision.delay
+		case decisionNow:
+		default:
+			delay, doRetry = backoffStrategy.Next()
+			// exit if delay is over
+			if !doRetry {
+				return finish(err, GiveUpBackoffExhausted)
+			}
+			delay = ro.delay(err, delay)
+		}
+		// exit if delay exceeds the deadline or the final attempt was performed
+		delay, final, doRetry = ro.fitDeadline(ctx, delay, final)
 		if !doRetry {
-			return err
+			if err != nil {
+				err = &deadlineError{err: err}
+			}
+			return finish(err, GiveUpWouldExceedDeadline)
 		}
 
-		// wait or cancel
-		select {
-		case <-ctx.Done():
+		// wait or cancel, cancelling without retrying if the context is already done
+		if !isDone(ctx) {
+			ro.hooks.retry(err, delay)
+			ro.traceWait(ctx, delay)
+			waitStart := time.Now()
+			select {
+			case <-ctx.Done():
+			case <-clock.After(delay):
+			}
+			ro.hooks.wait(time.Since(waitStart))
+		}
+		if isDone(ctx) {
 			if err != nil {
-				return fmt.Errorf("%v, %w", ctx.Err(), err)
+				err = newContextError(ctx, err)
 			}
-			return nil
-		case <-clock.After(delay):
+			return finish(err, GiveUpContextDone)
 		}
+
+		if ro.retryError && err != nil {
+			history[len(history)-1].Delay = delay
+		}
+		attempt = Attempt{Number: attempt.Number + 1, PreviousError: err, Waited: delay}
+	}
+}
+
+// ErrWouldExceedDeadline is wrapped by the error returned when retry gives up
+// because the next retry would exceed the deadline of the context.
+var ErrWouldExceedDeadline = errors.New("retry would exceed deadline")
+
+type deadlineError struct {
+	err error
+}
+
+// Error returns the error in string format.
+func (de *deadlineError) Error() string {
+	return ErrWouldExceedDeadline.Error() + ", " + de.err.Error()
+}
+
+// Is reports the error as ErrWouldExceedDeadline.
+func (de *deadlineError) Is(target error) bool {
+	return target == ErrWouldExceedDeadline
+}
+
+// Unwrap provides the error of the last attempt.
+func (de *deadlineError) Unwrap() error {
+	return de.err
+}
+
+// isDone reports if the context is done, without blocking.
+func isDone(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+// ignoreAttempt adapts a function ignoring the context and the attempt.
+func ignoreAttempt(f func() error) func(context.Context, Attempt) error {
+	return func(context.Context, Attempt) error {
+		return f()
 	}
 }
 
@@ -94,6 +218,18 @@ func RetryRecoverablePolicy(err error) bool {
 	return found && recover
 }
 
+// RetryRecoverablePolicyWith works like RetryRecoverablePolicy, using the provided resolution
+// for errors wrapping multiple errors.
+func RetryRecoverablePolicyWith(resolution Resolution) RetryPolicy {
+	return func(err error) bool {
+		if err == nil {
+			return false
+		}
+		found, recover := DoRecoverWith(err, resolution)
+		return found && recover
+	}
+}
+
 // RetryNonUnrecoverablePolicy defines if retry should be performed after receiving
 // the provided error by the retry mechanism.
 //
@@ -109,6 +245,18 @@ func RetryNonUnrecoverablePolicy(err error) bool {
 	return !found || recover
 }
 
+// RetryNonUnrecoverablePolicyWith works like RetryNonUnrecoverablePolicy, using the provided resolution
+// for errors wrapping multiple errors.
+func RetryNonUnrecoverablePolicyWith(resolution Resolution) RetryPolicy {
+	return func(err error) bool {
+		if err == nil {
+			return false
+		}
+		found, recover := DoRecoverWith(err, resolution)
+		return !found || recover
+	}
+}
+
 // RetryForever is a retry policy that defines that whatever the input to be evaluated,
 // retry should be performed.
 func RetryForever(err error) bool {
@@ -120,6 +268,25 @@ type BackoffStrategy interface {
 	Next() (time.Duration, bool)
 }
 
+// ResettableBackoffStrategy is a backoff strategy that can restart its schedule.
+type ResettableBackoffStrategy interface {
+	BackoffStrategy
+	Reset()
+}
+
+// CloneableBackoffStrategy is a backoff strategy that can create a copy of itself,
+// using the same parameters and a restarted schedule.
+type CloneableBackoffStrategy interface {
+	BackoffStrategy
+	Clone() BackoffStrategy
+}
+
+// BackoffFactory creates a factory of backoff strategies cloning the given prototype,
+// to be used by Do or Retrier.
+func BackoffFactory(prototype CloneableBackoffStrategy) func() BackoffStrategy {
+	return prototype.Clone
+}
+
 // Clock replaces time package to provide mock replacements.
 type Clock interface {
 	After(time.Duration) <-chan time.Time
@@ -0,0 +1,110 @@
+package recovererr
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// GiveUpReason describes why the retry mechanism stopped retrying.
+type GiveUpReason int
+
+const (
+	// GiveUpUnrecoverable stops retrying on an unrecoverable error.
+	GiveUpUnrecoverable GiveUpReason = iota + 1
+	// GiveUpPolicyStopped stops retrying on an error the retry policy rejected.
+	GiveUpPolicyStopped
+	// GiveUpBackoffExhausted stops retrying when the backoff strategy is over.
+	GiveUpBackoffExhausted
+	// GiveUpContextDone stops retrying when the context is done.
+	GiveUpContextDone
+	// GiveUpWouldExceedDeadline stops retrying when the next retry would exceed the deadline of the context.
+	GiveUpWouldExceedDeadline
+)
+
+// String returns the reason in string format.
+func (r GiveUpReason) String() string {
+	switch r {
+	case GiveUpUnrecoverable:
+		return "unrecoverable"
+	case GiveUpPolicyStopped:
+		return "policy stopped"
+	case GiveUpBackoffExhausted:
+		return "backoff exhausted"
+	case GiveUpContextDone:
+		return "context done"
+	case GiveUpWouldExceedDeadline:
+		return "would exceed deadline"
+	}
+	return fmt.Sprintf("reason(%d)", int(r))
+}
+
+// AttemptRecord describes a failed attempt of the retry mechanism.
+type AttemptRecord struct {
+	Err      error
+	Start    time.Time
+	Duration time.Duration
+	// Delay is the delay waited after the attempt, zero for the last attempt.
+	Delay time.Duration
+}
+
+// RetryError is returned by the retry mechanism when it gives up,
+// if configured by WithRetryError.
+type RetryError struct {
+	// Err is the error the retry mechanism would return otherwise.
+	Err      error
+	Reason   GiveUpReason
+	Attempts []AttemptRecord
+}
+
+// Error returns the error in string format.
+func (re *RetryError) Error() string {
+	return re.Err.Error()
+}
+
+// Is reports if the returned error or the error of any attempt matches the target,
+// since errors.Is ignores Unwrap() []error before Go 1.20.
+func (re *RetryError) Is(target error) bool {
+	for _, err := range re.Unwrap() {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error matching the target, in the returned error or the errors of the attempts,
+// since errors.As ignores Unwrap() []error before Go 1.20.
+func (re *RetryError) As(target interface{}) bool {
+	for _, err := range re.Unwrap() {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unwrap provides the returned error followed by the errors of all attempts.
+func (re *RetryError) Unwrap() []error {
+	errs := make([]error, 0, len(re.Attempts)+1)
+	errs = append(errs, re.Err)
+	for _, a := range re.Attempts {
+		if a.Err != nil {
+			errs = append(errs, a.Err)
+		}
+	}
+	return errs
+}
+
+// recoveryCause provides the error providing the recovery context.
+func (re *RetryError) recoveryCause() error {
+	return re.Err
+}
+
+// giveUpReason provides the reason of giving up after the error was rejected by the retry policy.
+func giveUpReason(err error) GiveUpReason {
+	if found, recover := DoRecover(err); found && !recover {
+		return GiveUpUnrecoverable
+	}
+	return GiveUpPolicyStopped
+}
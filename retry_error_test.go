@@ -0,0 +1,154 @@
+package recovererr
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryError(t *testing.T) {
+	t.Parallel()
+
+	var (
+		recoverable   = Recoverable(errors.New("connection error"))
+		unrecoverable = Unrecoverable(errors.New("parse error"))
+	)
+
+	cancelledCtx, cancelFunc := context.WithCancel(context.Background())
+	cancelFunc()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		clock    Clock
+		errors   []error
+		policy   RetryPolicy
+		opts     []RetryOption
+		reason   GiveUpReason
+		attempts int
+		found    bool
+		recover  bool
+	}{
+		{
+			name:     "unrecoverable",
+			ctx:      context.Background(),
+			errors:   []error{recoverable, unrecoverable},
+			policy:   RetryRecoverablePolicy,
+			reason:   GiveUpUnrecoverable,
+			attempts: 2,
+			found:    true,
+			recover:  false,
+		},
+		{
+			name:     "policy stopped",
+			ctx:      context.Background(),
+			errors:   []error{recoverable, errors.New("any error")},
+			policy:   RetryRecoverablePolicy,
+			reason:   GiveUpPolicyStopped,
+			attempts: 2,
+			recover:  false,
+		},
+		{
+			name:     "backoff exhausted",
+			ctx:      context.Background(),
+			errors:   []error{unrecoverable, recoverable},
+			policy:   RetryForever,
+			reason:   GiveUpBackoffExhausted,
+			attempts: 4,
+			found:    true,
+			recover:  true,
+		},
+		{
+			name:     "context done",
+			ctx:      cancelledCtx,
+			clock:    &SystemClock{},
+			errors:   []error{recoverable},
+			policy:   RetryRecoverablePolicy,
+			reason:   GiveUpContextDone,
+			attempts: 1,
+			found:    true,
+			recover:  true,
+		},
+		{
+			name:     "would exceed deadline",
+			ctx:      &mockContext{deadlineTime: time.Now().Add(time.Microsecond), deadlineSet: true},
+			errors:   []error{recoverable},
+			policy:   RetryRecoverablePolicy,
+			opts:     []RetryOption{WithDeadlineGiveUp()},
+			reason:   GiveUpWouldExceedDeadline,
+			attempts: 1,
+			found:    true,
+			recover:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action := &mockAction{errors: tt.errors}
+			var clock Clock = &mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+			if tt.clock != nil {
+				clock = tt.clock
+			}
+			opts := append([]RetryOption{WithRetryError()}, tt.opts...)
+
+			err := retry(tt.ctx, action.Call, clock, NewConstantBackoff(WithInterval(time.Millisecond), WithMaxAttempts(3)), tt.policy, opts...)
+
+			var re *RetryError
+			assert.True(t, errors.As(err, &re), err)
+			assert.Equal(t, tt.reason, re.Reason)
+			assert.Len(t, re.Attempts, tt.attempts)
+			found, recover := DoRecover(err)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.recover, recover)
+			for i, a := range re.Attempts {
+				assert.False(t, a.Start.IsZero())
+				if i < len(re.Attempts)-1 {
+					assert.Equal(t, time.Millisecond, a.Delay)
+				} else {
+					assert.Equal(t, time.Duration(0), a.Delay)
+				}
+			}
+		})
+	}
+
+	t.Run("unwrap errors of attempts", func(t *testing.T) {
+		first, last := Recoverable(errors.New("first")), Unrecoverable(errors.New("last"))
+		action := &mockAction{errors: []error{first, last}}
+		newBackoff := func() BackoffStrategy {
+			return NewConstantBackoff(WithInterval(time.Millisecond))
+		}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		err := do(context.Background(), action.Call, &mockClock, newBackoff, RetryRecoverablePolicy, WithRetryError())
+
+		assert.Equal(t, "unrecover: last", err.Error())
+		assert.True(t, errors.Is(err, first))
+		assert.True(t, errors.Is(err, last))
+		assert.Equal(t, []error{last, first, last}, err.(*RetryError).Unwrap())
+	})
+
+	t.Run("is and as without unwrapping multiple errors", func(t *testing.T) {
+		first, last := Recoverable(errors.New("first")), &HTTPResponseError{StatusCode: 503}
+		re := &RetryError{Err: last, Attempts: []AttemptRecord{{Err: first}, {Err: last}}}
+
+		assert.True(t, re.Is(first))
+		assert.True(t, re.Is(last))
+		assert.False(t, re.Is(context.Canceled))
+		var he *HTTPResponseError
+		assert.True(t, re.As(&he))
+		assert.Equal(t, last, he)
+		var ce *ContextError
+		assert.False(t, re.As(&ce))
+	})
+
+	t.Run("no error on success", func(t *testing.T) {
+		action := &mockAction{errors: []error{Recoverable(errors.New("failure")), nil}}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		err := retry(context.Background(), action.Call, &mockClock, NewConstantBackoff(WithInterval(time.Millisecond)), RetryRecoverablePolicy, WithRetryError())
+
+		assert.Nil(t, err)
+	})
+}
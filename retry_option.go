@@ -0,0 +1,225 @@
+package recovererr
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// RetryOption configures the retry mechanism.
+type RetryOption func(*retryOptions)
+
+type retryOptions struct {
+	retryAfterMode RetryAfterMode
+	panicClass     Class
+	attemptTimeout time.Duration
+
+	deadlineMode   deadlineMode
+	deadlineMargin time.Duration
+
+	retryError     bool
+	resetOnSuccess bool
+
+	hooks           hooks
+	attemptPolicies []AttemptPolicy
+	decisionPolicy  DecisionPolicy
+
+	operation string
+	tracing   bool
+}
+
+func newRetryOptions(opts ...RetryOption) *retryOptions {
+	ro := retryOptions{}
+
+	for _, opt := range opts {
+		opt(&ro)
+	}
+
+	return &ro
+}
+
+// RetryAfterMode defines how the retry delay hint of an error is combined
+// with the delay provided by the backoff strategy.
+type RetryAfterMode int
+
+const (
+	// RetryAfterMax waits for the larger of the hint and the backoff delay.
+	RetryAfterMax RetryAfterMode = iota
+	// RetryAfterOverride waits for the hint instead of the backoff delay.
+	RetryAfterOverride
+	// RetryAfterIgnore waits for the backoff delay ignoring any hint.
+	RetryAfterIgnore
+)
+
+// WithRetryAfterMode configures how retry delay hints are honoured.
+func WithRetryAfterMode(mode RetryAfterMode) RetryOption {
+	return func(ro *retryOptions) {
+		ro.retryAfterMode = mode
+	}
+}
+
+// WithAttemptTimeout configures retry to run each attempt using a context
+// cancelled after the given timeout.
+//
+// An attempt failing after exceeding the timeout returns a recoverable error
+// wrapping ErrAttemptTimeout, unless the error carries its own recovery context,
+// while exceeding the deadline of the parent context still stops the retries.
+func WithAttemptTimeout(d time.Duration) RetryOption {
+	return func(ro *retryOptions) {
+		ro.attemptTimeout = d
+	}
+}
+
+// WithRetryError configures retry to return a *RetryError when giving up,
+// recording the give up reason and the history of the failed attempts.
+func WithRetryError() RetryOption {
+	return func(ro *retryOptions) {
+		ro.retryError = true
+	}
+}
+
+// WithResetOnSuccess configures retry to restart the schedule of a
+// ResettableBackoffStrategy after a successful call, when the retry policy
+// keeps retrying, e.g. RetryForever.
+func WithResetOnSuccess() RetryOption {
+	return func(ro *retryOptions) {
+		ro.resetOnSuccess = true
+	}
+}
+
+// WithAttemptPolicy configures retry to also consult the given attempt policy,
+// performing retry only when both the retry policy and the attempt policy allow it.
+func WithAttemptPolicy(policy AttemptPolicy) RetryOption {
+	return func(ro *retryOptions) {
+		ro.attemptPolicies = append(ro.attemptPolicies, policy)
+	}
+}
+
+// allowRetry reports if all attempt policies allow retry.
+func (ro *retryOptions) allowRetry(err error, state RetryState) bool {
+	for _, policy := range ro.attemptPolicies {
+		if !policy(err, state) {
+			return false
+		}
+	}
+	return true
+}
+
+// WithDecisionPolicy configures retry to execute the decision of the given policy,
+// when the retry policy and the attempt policies allow retry.
+func WithDecisionPolicy(policy DecisionPolicy) RetryOption {
+	return func(ro *retryOptions) {
+		ro.decisionPolicy = policy
+	}
+}
+
+// decide provides the decision of the decision policy, if configured.
+func (ro *retryOptions) decide(err error, state RetryState) Decision {
+	if ro.decisionPolicy == nil {
+		return RetryWithBackoff
+	}
+	return ro.decisionPolicy(err, state)
+}
+
+type deadlineMode int
+
+const (
+	deadlineWait deadlineMode = iota
+	deadlineGiveUp
+	deadlineShorten
+)
+
+// WithDeadlineGiveUp configures retry to give up immediately when the delay
+// before the next retry would exceed the deadline of the context,
+// returning an error wrapping ErrWouldExceedDeadline.
+func WithDeadlineGiveUp() RetryOption {
+	return func(ro *retryOptions) {
+		ro.deadlineMode = deadlineGiveUp
+	}
+}
+
+// WithDeadlineShorten configures retry to shorten the delay before the next retry
+// when it would exceed the deadline of the context, so that the final retry
+// is performed the given margin before the deadline.
+// Retry gives up, returning an error wrapping ErrWouldExceedDeadline, after the final
+// retry or when the deadline is already within the margin.
+func WithDeadlineShorten(margin time.Duration) RetryOption {
+	return func(ro *retryOptions) {
+		ro.deadlineMode = deadlineShorten
+		ro.deadlineMargin = margin
+	}
+}
+
+// fitDeadline adjusts the delay before the next retry to the deadline of the context,
+// reporting if the delay was shortened, making the next attempt the final one.
+// It returns false when retry should give up, including after a final attempt.
+func (ro *retryOptions) fitDeadline(ctx context.Context, delay time.Duration, final bool) (time.Duration, bool, bool) {
+	if ro.deadlineMode == deadlineWait {
+		return delay, false, true
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return delay, false, true
+	}
+	if final {
+		return 0, false, false
+	}
+	remaining := time.Until(deadline)
+	if delay <= remaining {
+		return delay, false, true
+	}
+	if ro.deadlineMode == deadlineGiveUp || remaining <= ro.deadlineMargin {
+		return 0, false, false
+	}
+	return remaining - ro.deadlineMargin, true, true
+}
+
+// wrap applies the options to the function.
+func (ro *retryOptions) wrap(f func(context.Context, Attempt) error) func(context.Context, Attempt) error {
+	if ro.panicClass != 0 {
+		next, class := f, ro.panicClass
+		f = func(ctx context.Context, a Attempt) error {
+			return safeClassified(func() error { return next(ctx, a) }, class)()
+		}
+	}
+	if ro.attemptTimeout > 0 {
+		next, timeout := f, ro.attemptTimeout
+		f = func(ctx context.Context, a Attempt) error {
+			attemptCtx, cancelFunc := context.WithTimeout(ctx, timeout)
+			defer cancelFunc()
+
+			err := next(attemptCtx, a)
+			if err != nil && ctx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded) && isAttemptTimeout(err) {
+				return Recoverable(&attemptTimeoutError{err: err})
+			}
+			return err
+		}
+	}
+	if ro.tracing {
+		f = ro.trace(f)
+	}
+	return f
+}
+
+// delay provides the delay to wait before retrying after the given error.
+func (ro *retryOptions) delay(err error, backoffDelay time.Duration) time.Duration {
+	if ro.retryAfterMode == RetryAfterIgnore {
+		return backoffDelay
+	}
+	hint, ok := RetryAfter(err)
+	if !ok {
+		return backoffDelay
+	}
+	if ro.retryAfterMode == RetryAfterOverride || hint > backoffDelay {
+		return hint
+	}
+	return backoffDelay
+}
+
+// WithPanicRecovery configures retry to recover panics of the function
+// into a *PanicError, wrapped with the given recovery class.
+func WithPanicRecovery(class Class) RetryOption {
+	return func(ro *retryOptions) {
+		ro.panicClass = class
+	}
+}
@@ -154,6 +154,7 @@ func (ma *mockAction) Call() error {
 type mockClock struct {
 	init     time.Time
 	interval time.Duration
+	delays   []time.Duration
 }
 
 func (mc *mockClock) Now() time.Time {
@@ -162,12 +163,259 @@ func (mc *mockClock) Now() time.Time {
 	return n
 }
 
-func (mc *mockClock) After(time.Duration) <-chan time.Time {
+func (mc *mockClock) After(d time.Duration) <-chan time.Time {
+	mc.delays = append(mc.delays, d)
 	ch := make(chan time.Time)
 	close(ch)
 	return ch
 }
 
+func TestRetry_retryAfter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		err    error
+		opts   []RetryOption
+		delays []time.Duration
+	}{
+		{
+			name:   "larger hint than backoff delay",
+			err:    RecoverableAfter(errors.New("throttled"), time.Second),
+			delays: []time.Duration{time.Second, time.Second},
+		},
+		{
+			name:   "smaller hint than backoff delay",
+			err:    RecoverableAfter(errors.New("throttled"), time.Microsecond),
+			delays: []time.Duration{time.Millisecond, time.Millisecond},
+		},
+		{
+			name:   "override backoff delay",
+			err:    RecoverableAfter(errors.New("throttled"), time.Microsecond),
+			opts:   []RetryOption{WithRetryAfterMode(RetryAfterOverride)},
+			delays: []time.Duration{time.Microsecond, time.Microsecond},
+		},
+		{
+			name:   "ignore hint",
+			err:    RecoverableAfter(errors.New("throttled"), time.Second),
+			opts:   []RetryOption{WithRetryAfterMode(RetryAfterIgnore)},
+			delays: []time.Duration{time.Millisecond, time.Millisecond},
+		},
+		{
+			name:   "no hint",
+			err:    Recoverable(errors.New("failure")),
+			delays: []time.Duration{time.Millisecond, time.Millisecond},
+		},
+		{
+			name:   "hinted wrapped error",
+			err:    fmt.Errorf("request failed, %w", RecoverableAfter(errors.New("throttled"), time.Second)),
+			delays: []time.Duration{time.Second, time.Second},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action := &mockAction{errors: []error{tt.err}}
+			mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+			_ = retry(context.Background(), action.Call, &mockClock, NewConstantBackoff(WithInterval(time.Millisecond), WithMaxAttempts(2)), RetryRecoverablePolicy, tt.opts...)
+
+			assert.Equal(t, tt.delays, mockClock.delays)
+		})
+	}
+
+	t.Run("do honours hint", func(t *testing.T) {
+		action := &mockAction{errors: []error{RecoverableAfter(errors.New("throttled"), time.Second), nil}}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+		newBackoff := func() BackoffStrategy {
+			return NewConstantBackoff(WithInterval(time.Millisecond), WithMaxAttempts(2))
+		}
+
+		err := do(context.Background(), action.Call, &mockClock, newBackoff, RetryRecoverablePolicy)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []time.Duration{time.Second}, mockClock.delays)
+	})
+}
+
+func TestRetry_deadline(t *testing.T) {
+	t.Parallel()
+
+	newContext := func(remaining time.Duration) context.Context {
+		return &mockContext{deadlineTime: time.Now().Add(remaining), deadlineSet: true}
+	}
+
+	t.Run("give up when delay exceeds deadline", func(t *testing.T) {
+		failure := Recoverable(errors.New("failure"))
+		action := &mockAction{errors: []error{failure}}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		err := retry(newContext(time.Minute), action.Call, &mockClock, NewConstantBackoff(WithInterval(time.Hour)), RetryRecoverablePolicy, WithDeadlineGiveUp())
+
+		assert.True(t, errors.Is(err, ErrWouldExceedDeadline), err)
+		assert.True(t, errors.Is(err, failure), err)
+		assert.Equal(t, 1, action.callCounter)
+		assert.Empty(t, mockClock.delays)
+	})
+
+	t.Run("retry when delay fits deadline", func(t *testing.T) {
+		action := &mockAction{errors: []error{Recoverable(errors.New("failure"))}}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		err := retry(newContext(time.Hour), action.Call, &mockClock, NewConstantBackoff(WithInterval(time.Second), WithMaxAttempts(2)), RetryRecoverablePolicy, WithDeadlineGiveUp())
+
+		assert.False(t, errors.Is(err, ErrWouldExceedDeadline), err)
+		assert.Equal(t, []time.Duration{time.Second, time.Second}, mockClock.delays)
+	})
+
+	t.Run("give up after successful call", func(t *testing.T) {
+		action := &mockAction{}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		err := retry(newContext(time.Minute), action.Call, &mockClock, NewConstantBackoff(WithInterval(time.Hour)), RetryForever, WithDeadlineGiveUp())
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, action.callCounter)
+	})
+
+	t.Run("shorten delay to deadline", func(t *testing.T) {
+		action := &mockAction{errors: []error{Recoverable(errors.New("failure"))}}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		_ = retry(newContext(time.Hour), action.Call, &mockClock, NewConstantBackoff(WithInterval(2*time.Hour), WithMaxAttempts(1)), RetryRecoverablePolicy, WithDeadlineShorten(time.Minute))
+
+		assert.Len(t, mockClock.delays, 1)
+		assert.True(t, mockClock.delays[0] <= 59*time.Minute && mockClock.delays[0] > 58*time.Minute, mockClock.delays[0])
+	})
+
+	t.Run("shorten delay beyond margin", func(t *testing.T) {
+		action := &mockAction{errors: []error{Recoverable(errors.New("failure"))}}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		err := retry(newContext(time.Second), action.Call, &mockClock, NewConstantBackoff(WithInterval(time.Hour), WithMaxAttempts(1)), RetryRecoverablePolicy, WithDeadlineShorten(time.Minute))
+
+		assert.True(t, errors.Is(err, ErrWouldExceedDeadline), err)
+		assert.Empty(t, mockClock.delays)
+	})
+
+	t.Run("shortened delay is final", func(t *testing.T) {
+		action := &mockAction{errors: []error{Recoverable(errors.New("failure"))}}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		err := retry(newContext(time.Hour), action.Call, &mockClock, NewConstantBackoff(WithInterval(2*time.Hour), WithMaxAttempts(-1)), RetryRecoverablePolicy, WithDeadlineShorten(time.Minute))
+
+		assert.True(t, errors.Is(err, ErrWouldExceedDeadline), err)
+		assert.Equal(t, 2, action.callCounter)
+		assert.Len(t, mockClock.delays, 1)
+	})
+
+	t.Run("delays fitting deadline are not final", func(t *testing.T) {
+		action := &mockAction{errors: []error{Recoverable(errors.New("failure"))}}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		err := retry(newContext(time.Hour), action.Call, &mockClock, NewConstantBackoff(WithInterval(time.Minute), WithMaxAttempts(5)), RetryRecoverablePolicy, WithDeadlineShorten(time.Minute))
+
+		assert.False(t, errors.Is(err, ErrWouldExceedDeadline), err)
+		assert.Equal(t, 6, action.callCounter)
+	})
+
+	t.Run("wait without deadline", func(t *testing.T) {
+		action := &mockAction{errors: []error{Recoverable(errors.New("failure"))}}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		_ = retry(context.Background(), action.Call, &mockClock, NewConstantBackoff(WithInterval(time.Hour), WithMaxAttempts(1)), RetryRecoverablePolicy, WithDeadlineGiveUp())
+
+		assert.Equal(t, []time.Duration{time.Hour}, mockClock.delays)
+	})
+}
+
+func TestBackoffStrategy_reset(t *testing.T) {
+	t.Parallel()
+
+	exhaust := func(bs BackoffStrategy) int {
+		n := 0
+		for _, ok := bs.Next(); ok; _, ok = bs.Next() {
+			n++
+		}
+		return n
+	}
+
+	t.Run("constant backoff", func(t *testing.T) {
+		cb := NewConstantBackoff(WithInterval(time.Millisecond), WithMaxAttempts(3))
+		assert.Equal(t, 3, exhaust(cb))
+
+		clone := cb.Clone()
+		cb.Reset()
+
+		assert.Equal(t, 3, exhaust(cb))
+		assert.Equal(t, 3, exhaust(clone))
+	})
+
+	t.Run("exponential backoff", func(t *testing.T) {
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+		eb := NewExponentialBackoff(
+			WithInitialInterval(time.Millisecond),
+			WithMultiplier(2),
+			WithMaxElapsedTime(4*time.Millisecond),
+			WithClock(&mockClock),
+		)
+		first, _ := eb.Next()
+		second, _ := eb.Next()
+		assert.Equal(t, 2*first, second)
+		exhaust(eb)
+
+		clone := eb.Clone()
+		eb.Reset()
+
+		d, ok := eb.Next()
+		assert.True(t, ok)
+		assert.Equal(t, first, d)
+		d, ok = clone.Next()
+		assert.True(t, ok)
+		assert.Equal(t, first, d)
+	})
+
+	t.Run("backoff factory", func(t *testing.T) {
+		newBackoff := BackoffFactory(NewConstantBackoff(WithInterval(time.Millisecond), WithMaxAttempts(2)))
+
+		for i := 0; i < 2; i++ {
+			action := &mockAction{errors: []error{Recoverable(errors.New("failure"))}}
+			mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+			_ = do(context.Background(), action.Call, &mockClock, newBackoff, RetryRecoverablePolicy)
+
+			assert.Equal(t, 3, action.callCounter)
+		}
+	})
+
+	t.Run("reset on success", func(t *testing.T) {
+		var (
+			failure = Recoverable(errors.New("failure"))
+			action  = &mockAction{errors: []error{failure, failure, nil, failure, failure, Unrecoverable(errors.New("stop"))}}
+		)
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+		policy := func(err error) bool { return err == nil || RetryRecoverablePolicy(err) }
+
+		err := retry(context.Background(), action.Call, &mockClock, NewConstantBackoff(WithInterval(time.Millisecond), WithMaxAttempts(3)), policy, WithResetOnSuccess())
+
+		assert.Equal(t, "unrecover: stop", err.Error())
+		assert.Equal(t, 6, action.callCounter)
+	})
+
+	t.Run("no reset on success", func(t *testing.T) {
+		var (
+			failure = Recoverable(errors.New("failure"))
+			action  = &mockAction{errors: []error{failure, failure, nil, failure, failure, Unrecoverable(errors.New("stop"))}}
+		)
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+		policy := func(err error) bool { return err == nil || RetryRecoverablePolicy(err) }
+
+		err := retry(context.Background(), action.Call, &mockClock, NewConstantBackoff(WithInterval(time.Millisecond), WithMaxAttempts(3)), policy)
+
+		assert.Equal(t, failure, err)
+		assert.Equal(t, 4, action.callCounter)
+	})
+}
+
 type customError struct {
 	recoverable bool
 	message     string
@@ -0,0 +1,48 @@
+package recovererr
+
+import "context"
+
+// DoValue works like Do, for a function returning a value.
+//
+// The value of the last call is returned on success,
+// while the zero value is returned on failure.
+func DoValue[T any](ctx context.Context, f func(context.Context) (T, error), newBackoffStrategy func() BackoffStrategy, retryPolicy RetryPolicy, opts ...RetryOption) (T, error) {
+	return doValue(ctx, f, &SystemClock{}, newBackoffStrategy, retryPolicy, opts...)
+}
+
+func doValue[T any](ctx context.Context, f func(context.Context) (T, error), clock Clock, newBackoffStrategy func() BackoffStrategy, retryPolicy RetryPolicy, opts ...RetryOption) (T, error) {
+	var v T
+	err := doAttempt(ctx, valueFunc(f, &v), clock, newBackoffStrategy, retryPolicy, opts...)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return v, nil
+}
+
+// RetryValue works like Retry, for a function returning a value.
+//
+// The value of the last call is returned on success,
+// while the zero value is returned on failure.
+func RetryValue[T any](ctx context.Context, f func(context.Context) (T, error), backoffStrategy BackoffStrategy, retryPolicy RetryPolicy, opts ...RetryOption) (T, error) {
+	return retryValue(ctx, f, &SystemClock{}, backoffStrategy, retryPolicy, opts...)
+}
+
+func retryValue[T any](ctx context.Context, f func(context.Context) (T, error), clock Clock, backoffStrategy BackoffStrategy, retryPolicy RetryPolicy, opts ...RetryOption) (T, error) {
+	var v T
+	err := retryAttempt(ctx, valueFunc(f, &v), clock, backoffStrategy, retryPolicy, opts...)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return v, nil
+}
+
+// valueFunc adapts a function returning a value, storing the value of each call.
+func valueFunc[T any](f func(context.Context) (T, error), v *T) func(context.Context, Attempt) error {
+	return func(ctx context.Context, _ Attempt) error {
+		var err error
+		*v, err = f(ctx)
+		return err
+	}
+}
@@ -0,0 +1,88 @@
+package recovererr
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryValue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("value after retry", func(t *testing.T) {
+		action := &mockAction{errors: []error{Recoverable(errors.New("failure")), nil}}
+		f := func(ctx context.Context) (int, error) {
+			err := action.Call()
+			return action.callCounter, err
+		}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		v, err := retryValue(context.Background(), f, &mockClock, NewConstantBackoff(WithInterval(time.Millisecond)), RetryRecoverablePolicy)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, v)
+	})
+
+	t.Run("zero value on failure", func(t *testing.T) {
+		failure := Recoverable(errors.New("failure"))
+		f := func(ctx context.Context) (string, error) {
+			return "partial", failure
+		}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		v, err := retryValue(context.Background(), f, &mockClock, NewConstantBackoff(WithInterval(time.Millisecond), WithMaxAttempts(2)), RetryRecoverablePolicy)
+
+		assert.Equal(t, failure, err)
+		assert.Equal(t, "", v)
+	})
+
+	t.Run("context passed to function", func(t *testing.T) {
+		type ctxKey struct{}
+		ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+		f := func(ctx context.Context) (interface{}, error) {
+			return ctx.Value(ctxKey{}), nil
+		}
+
+		v, err := RetryValue(ctx, f, NewConstantBackoff(), RetryRecoverablePolicy)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "value", v)
+	})
+}
+
+func TestDoValue(t *testing.T) {
+	t.Parallel()
+
+	newBackoff := func() BackoffStrategy {
+		return NewConstantBackoff(WithInterval(time.Millisecond), WithMaxAttempts(2))
+	}
+
+	t.Run("value after retry", func(t *testing.T) {
+		action := &mockAction{errors: []error{errors.New("failure"), nil}}
+		f := func(ctx context.Context) ([]int, error) {
+			err := action.Call()
+			return []int{action.callCounter}, err
+		}
+		mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+		v, err := doValue(context.Background(), f, &mockClock, newBackoff, RetryNonUnrecoverablePolicy)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []int{2}, v)
+	})
+
+	t.Run("zero value on failure", func(t *testing.T) {
+		f := func(ctx context.Context) (*int, error) {
+			v := 1
+			return &v, Unrecoverable(errors.New("failure"))
+		}
+
+		v, err := DoValue(context.Background(), f, newBackoff, RetryNonUnrecoverablePolicy)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, v)
+	})
+}
@@ -0,0 +1,13 @@
+//go:build !plan9
+
+package stdclass
+
+import "syscall"
+
+// transientErrnos are the system call errors of transient network failures.
+var transientErrnos = []error{
+	syscall.ECONNRESET,
+	syscall.ECONNREFUSED,
+	syscall.EPIPE,
+	syscall.EAGAIN,
+}
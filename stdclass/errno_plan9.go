@@ -0,0 +1,5 @@
+package stdclass
+
+// transientErrnos are the system call errors of transient network failures,
+// which plan9 reports as strings instead.
+var transientErrnos []error
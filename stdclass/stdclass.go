@@ -0,0 +1,79 @@
+// This package provides classification of standard library errors,
+// to be registered as a recovererr classifier.
+package stdclass
+
+import (
+	"context"
+	"crypto/x509"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"os"
+
+	"github.com/sermojohn/go-recovererr"
+)
+
+// Register registers Classify to be consulted by recovererr.DoRecover.
+func Register() {
+	recovererr.RegisterClassifier(Classify)
+}
+
+// Classify provides the recovery context of standard library errors.
+//
+// Recoverable errors:
+// 1. network timeouts and dial failures
+// 2. connection reset, refused, broken pipe and resource unavailable system errors
+// 3. unexpected EOF
+// 4. bad database driver connections
+// 5. expired context deadline
+//
+// Unrecoverable errors:
+// 1. certificate verification failures
+// 2. permission failures
+// 3. cancelled context
+func Classify(err error) (found, recover bool) {
+	if err == nil {
+		return false, false
+	}
+	if isPermanent(err) {
+		return true, false
+	}
+	if isTransient(err) {
+		return true, true
+	}
+	return false, false
+}
+
+func isPermanent(err error) bool {
+	var (
+		unknownAuthorityErr x509.UnknownAuthorityError
+		certificateErr      x509.CertificateInvalidError
+		hostnameErr         x509.HostnameError
+	)
+	return errors.As(err, &unknownAuthorityErr) ||
+		errors.As(err, &certificateErr) ||
+		errors.As(err, &hostnameErr) ||
+		errors.Is(err, os.ErrPermission) ||
+		errors.Is(err, context.Canceled)
+}
+
+func isTransient(err error) bool {
+	for _, target := range append(transientErrnos,
+		io.ErrUnexpectedEOF,
+		driver.ErrBadConn,
+		context.DeadlineExceeded,
+	) {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,51 @@
+package stdclass
+
+import (
+	"context"
+	"crypto/x509"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		err     error
+		found   bool
+		recover bool
+	}{
+		{"nil error", nil, false, false},
+		{"any error", errors.New("any error"), false, false},
+		{"connection reset", &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, true, true},
+		{"connection refused", fmt.Errorf("connect, %w", syscall.ECONNREFUSED), true, true},
+		{"broken pipe", syscall.EPIPE, true, true},
+		{"resource unavailable", syscall.EAGAIN, true, true},
+		{"dial failure", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("no route to host")}, true, true},
+		{"network timeout", &net.DNSError{Err: "timeout", IsTimeout: true}, true, true},
+		{"unexpected EOF", fmt.Errorf("decode, %w", io.ErrUnexpectedEOF), true, true},
+		{"bad driver connection", driver.ErrBadConn, true, true},
+		{"deadline exceeded", context.DeadlineExceeded, true, true},
+		{"cancelled context", context.Canceled, true, false},
+		{"unknown authority", fmt.Errorf("handshake, %w", x509.UnknownAuthorityError{}), true, false},
+		{"invalid certificate", x509.CertificateInvalidError{Reason: x509.Expired}, true, false},
+		{"hostname mismatch", x509.HostnameError{Host: "example.com"}, true, false},
+		{"permission denied", &os.PathError{Op: "open", Path: "/etc/shadow", Err: os.ErrPermission}, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, recover := Classify(tt.err)
+			assert.Equal(t, tt.found, found, tt.err)
+			assert.Equal(t, tt.recover, recover, tt.err)
+		})
+	}
+}
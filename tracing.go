@@ -0,0 +1,51 @@
+package recovererr
+
+import (
+	"context"
+	"runtime/pprof"
+	"runtime/trace"
+	"strconv"
+	"time"
+)
+
+// WithTracing configures retry to run within a runtime/trace task named after the operation,
+// configured by WithOperation, and each attempt within a trace region named "attempt N"
+// and pprof labels of the operation and the attempt.
+// The backoff waits are recorded as trace log events.
+// The task ends when retry returns, even if the function panics.
+func WithTracing() RetryOption {
+	return func(ro *retryOptions) {
+		ro.tracing = true
+	}
+}
+
+// startTask starts the trace task of retry, if configured,
+// returning its context and the function ending it.
+func (ro *retryOptions) startTask(ctx context.Context) (context.Context, func()) {
+	if !ro.tracing {
+		return ctx, func() {}
+	}
+	ctx, task := trace.NewTask(ctx, ro.operationName())
+	return ctx, task.End
+}
+
+// traceWait logs the backoff wait as a trace event, if configured.
+func (ro *retryOptions) traceWait(ctx context.Context, delay time.Duration) {
+	if ro.tracing {
+		trace.Log(ctx, "backoff", "wait "+delay.String())
+	}
+}
+
+// trace wraps the function to run each attempt within a trace region and pprof labels.
+func (ro *retryOptions) trace(f func(context.Context, Attempt) error) func(context.Context, Attempt) error {
+	return func(ctx context.Context, a Attempt) error {
+		var err error
+		attempt := strconv.Itoa(a.Number)
+		pprof.Do(ctx, pprof.Labels("operation", ro.operationName(), "attempt", attempt), func(ctx context.Context) {
+			trace.WithRegion(ctx, "attempt "+attempt, func() {
+				err = f(ctx, a)
+			})
+		})
+		return err
+	}
+}
@@ -0,0 +1,42 @@
+package recovererr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"runtime/pprof"
+	"runtime/trace"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetry_tracing(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	assert.NoError(t, trace.Start(&buf))
+
+	type labels struct{ operation, attempt string }
+	var got []labels
+	f := func(ctx context.Context, a Attempt) error {
+		operation, _ := pprof.Label(ctx, "operation")
+		attempt, _ := pprof.Label(ctx, "attempt")
+		got = append(got, labels{operation, attempt})
+		if a.Number < 3 {
+			return errors.New("failure")
+		}
+		return nil
+	}
+	mockClock := mockClock{init: time.Unix(1659219915, 0), interval: time.Millisecond}
+
+	err := retryAttempt(context.Background(), f, &mockClock, NewConstantBackoff(WithInterval(time.Millisecond)), RetryNonUnrecoverablePolicy,
+		WithTracing(), WithOperation("fetch"),
+	)
+	trace.Stop()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []labels{{"fetch", "1"}, {"fetch", "2"}, {"fetch", "3"}}, got)
+	assert.Contains(t, buf.String(), "attempt 3")
+}
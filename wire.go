@@ -0,0 +1,144 @@
+package recovererr
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// wireError is the JSON representation of an error and its recovery context.
+type wireError struct {
+	Message    string     `json:"message"`
+	Class      Class      `json:"class,omitempty"`
+	Reason     string     `json:"reason,omitempty"`
+	Dependency string     `json:"dependency,omitempty"`
+	Attrs      []wireAttr `json:"attrs,omitempty"`
+	RetryAt    *time.Time `json:"retry_at,omitempty"`
+	Causes     []string   `json:"causes,omitempty"`
+}
+
+type wireAttr struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+// MarshalError encodes the error to JSON, along with its recovery context,
+// metadata, retry delay hint and the messages of the wrapped errors.
+func MarshalError(err error) ([]byte, error) {
+	if err == nil {
+		return nil, errors.New("recovererr: error cannot be nil")
+	}
+
+	we := wireError{Message: err.Error()}
+	if found, class := DoClassify(err); found {
+		we.Class = class
+	}
+	we.Reason, _ = Reason(err)
+	we.Dependency, _ = Dependency(err)
+	for _, attr := range Attrs(err) {
+		we.Attrs = append(we.Attrs, wireAttr(attr))
+	}
+	if d, ok := RetryAfter(err); ok {
+		at := time.Now().Add(d).UTC()
+		we.RetryAt = &at
+	}
+	for cause := errors.Unwrap(err); cause != nil; cause = errors.Unwrap(cause) {
+		we.Causes = append(we.Causes, cause.Error())
+	}
+
+	return json.Marshal(we)
+}
+
+// UnmarshalError decodes an error encoded by MarshalError.
+//
+// The decoded error provides the encoded recovery context to DoRecover,
+// and unwraps to errors carrying the messages of the encoded wrapped errors.
+func UnmarshalError(data []byte) (error, error) {
+	var we wireError
+	if err := json.Unmarshal(data, &we); err != nil {
+		return nil, err
+	}
+
+	var cause error
+	for i := len(we.Causes) - 1; i >= 0; i-- {
+		cause = &remoteError{message: we.Causes[i], cause: cause}
+	}
+	re := &remoteError{message: we.Message, cause: cause}
+	if we.Class == 0 {
+		return re, nil
+	}
+
+	rre := &remoteRecoveryError{
+		remoteError: re,
+		class:       we.Class,
+		reason:      we.Reason,
+		dependency:  we.Dependency,
+	}
+	for _, attr := range we.Attrs {
+		rre.attrs = append(rre.attrs, Attr(attr))
+	}
+	if we.RetryAt != nil {
+		rre.at = *we.RetryAt
+	}
+	return rre, nil
+}
+
+// remoteError is an error decoded by UnmarshalError.
+type remoteError struct {
+	message string
+	cause   error
+}
+
+// Error returns the error in string format.
+func (re *remoteError) Error() string {
+	return re.message
+}
+
+// Unwrap provides the wrapped error.
+func (re *remoteError) Unwrap() error {
+	return re.cause
+}
+
+// remoteRecoveryError is an error decoded by UnmarshalError carrying recovery context.
+type remoteRecoveryError struct {
+	*remoteError
+
+	class      Class
+	reason     string
+	dependency string
+	attrs      []Attr
+	at         time.Time
+}
+
+// Recover provides if should recover from error.
+func (rre *remoteRecoveryError) Recover() bool {
+	return rre.class.Recover()
+}
+
+// RecoveryClass provides the recovery class of the error.
+func (rre *remoteRecoveryError) RecoveryClass() Class {
+	return rre.class
+}
+
+// RetryAfter provides the delay to wait before retrying, if hinted.
+func (rre *remoteRecoveryError) RetryAfter() (time.Duration, bool) {
+	if rre.at.IsZero() {
+		return 0, false
+	}
+	return time.Until(rre.at), true
+}
+
+// Reason provides the reason code of the error.
+func (rre *remoteRecoveryError) Reason() string {
+	return rre.reason
+}
+
+// Dependency provides the name of the failed dependency.
+func (rre *remoteRecoveryError) Dependency() string {
+	return rre.dependency
+}
+
+// Attrs provides the attributes of the error.
+func (rre *remoteRecoveryError) Attrs() []Attr {
+	return rre.attrs
+}
@@ -0,0 +1,85 @@
+package recovererr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round trip of recovery context", func(t *testing.T) {
+		original := fmt.Errorf("failed to charge, %w", RecoverableAfter(errors.New("too many requests"), time.Hour,
+			WithReason("payments.throttled"),
+			WithDependency("payments"),
+			WithAttr("status", 429),
+		))
+
+		data, err := MarshalError(original)
+		assert.Nil(t, err)
+		decoded, err := UnmarshalError(data)
+		assert.Nil(t, err)
+
+		assert.Equal(t, original.Error(), decoded.Error())
+		assert.True(t, RetryRecoverablePolicy(decoded))
+		found, class := DoClassify(decoded)
+		assert.True(t, found)
+		assert.Equal(t, ClassTransient, class)
+		reason, _ := Reason(decoded)
+		assert.Equal(t, "payments.throttled", reason)
+		dependency, _ := Dependency(decoded)
+		assert.Equal(t, "payments", dependency)
+		assert.Equal(t, []Attr{{Key: "status", Value: float64(429)}}, Attrs(decoded))
+		d, ok := RetryAfter(decoded)
+		assert.True(t, ok)
+		assert.True(t, d > 59*time.Minute && d <= time.Hour, d)
+	})
+
+	t.Run("round trip of causes", func(t *testing.T) {
+		original := fmt.Errorf("failed to parse, %w", Classified(errors.New("invalid payload"), ClassFatal))
+
+		data, err := MarshalError(original)
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{
+			"message": "failed to parse, unrecover: invalid payload",
+			"class": "fatal",
+			"causes": ["unrecover: invalid payload", "invalid payload"]
+		}`, string(data))
+
+		decoded, err := UnmarshalError(data)
+		assert.Nil(t, err)
+
+		assert.False(t, RetryNonUnrecoverablePolicy(decoded))
+		cause := errors.Unwrap(decoded)
+		assert.Equal(t, "unrecover: invalid payload", cause.Error())
+		assert.Equal(t, "invalid payload", errors.Unwrap(cause).Error())
+		assert.Nil(t, errors.Unwrap(errors.Unwrap(cause)))
+	})
+
+	t.Run("no recovery context", func(t *testing.T) {
+		data, err := MarshalError(errors.New("any error"))
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"message": "any error"}`, string(data))
+
+		decoded, err := UnmarshalError(data)
+		assert.Nil(t, err)
+
+		found, _ := DoRecover(decoded)
+		assert.False(t, found)
+		assert.Equal(t, "any error", decoded.Error())
+	})
+
+	t.Run("nil error", func(t *testing.T) {
+		_, err := MarshalError(nil)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("invalid class", func(t *testing.T) {
+		_, err := UnmarshalError([]byte(`{"message": "any error", "class": "unknown"}`))
+		assert.NotNil(t, err)
+	})
+}